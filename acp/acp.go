@@ -0,0 +1,65 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package acp implements DefraDB's access-control layer: named policies, scoped to a document,
+// collection, or field, evaluated against the actor identity attached to a context.Context by
+// [WithActor].
+//
+// [client.Store]'s schema and snapshot operations consult a policy (see [CheckRead], [CheckWrite],
+// [CheckRelate]) before they take effect. [RedactRows] is built so that a [RequestResult]'s rows
+// can be passed through it to null out a field an actor cannot read, with a structured
+// [client.RedactedField] entry explaining why, rather than failing the whole request - but no
+// request-execution pipeline in this source snapshot calls it yet; see the note on
+// [client.Store.ExecRequest].
+//
+// acp is opt-in: a policy name that nothing has [RegisterPolicy]'d is treated as "no restriction
+// configured" and every check against it passes - see [Check] for the full evaluation rule.
+//
+// Extending enforcement into the CRDT commit path, so that a replicated update arriving over P2P
+// from a peer lacking write rights is rejected rather than merged, requires a hook in the merge/
+// net subsystems that this source snapshot does not contain; [CheckWrite] is the function such a
+// hook would call. The same is true of [client.DB.NewTxn]/NewConcurrentTxn and of ExecRequest
+// (see above) - none of client.Store's actual read/write surface is gated yet, only the
+// schema/snapshot operations named above.
+//
+// The rule-evaluation logic itself ([Check] and [Rule.matches]) does not depend on any of that
+// missing wiring and is unit-tested in this package independently of it.
+package acp
+
+import "context"
+
+// Actor identifies the principal a policy decision is evaluated against - either a raw public key
+// or a DID-style string principal (e.g. "did:key:z6Mk...").
+type Actor struct {
+	// ID is the actor's identifier: a public key or DID string, opaque to this package.
+	ID string
+}
+
+// IsZero reports whether a is the zero Actor, i.e. no identity was attached to the context - an
+// anonymous actor.
+func (a Actor) IsZero() bool {
+	return a.ID == ""
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a copy of ctx carrying actor, so that [CheckRead], [CheckWrite],
+// [CheckRelate], and [RedactRows] evaluate policy against it rather than treating the request as
+// anonymous.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the Actor previously attached to ctx by [WithActor], and false if none
+// was attached - an anonymous request.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(Actor)
+	return actor, ok
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActor_IsZero(t *testing.T) {
+	assert.True(t, Actor{}.IsZero())
+	assert.False(t, Actor{ID: "someone"}.IsZero())
+}
+
+func TestWithActor_RoundTripsThroughContext(t *testing.T) {
+	actor, ok := ActorFromContext(context.Background())
+	assert.False(t, ok, "no actor should be attached to a bare context")
+	assert.True(t, actor.IsZero())
+
+	ctx := WithActor(context.Background(), Actor{ID: "someone"})
+	actor, ok = ActorFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "someone", actor.ID)
+}
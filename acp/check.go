@@ -0,0 +1,52 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import "context"
+
+// Check evaluates whether the actor attached to ctx (the anonymous Actor, if none is attached)
+// holds permission on resource under the policy named policyName, returning an
+// [NewErrPermissionDenied] error if not.
+//
+// A policyName that nothing has [RegisterPolicy]'d is treated as "no restriction configured
+// under this name" - acp is opt-in, so an unconfigured policy leaves the operation ungated
+// rather than defaulting to deny. Once a policy of that name is registered, however, only its
+// Rules grant access - a resource/permission pair with no matching rule is denied.
+func Check(ctx context.Context, policyName string, resource Resource, permission Permission) error {
+	policy, ok := lookupPolicy(policyName)
+	if !ok {
+		return nil
+	}
+
+	actor, _ := ActorFromContext(ctx)
+	for _, rule := range policy.Rules {
+		if rule.matches(resource, permission, actor) {
+			return nil
+		}
+	}
+
+	return NewErrPermissionDenied(actor, permission, resource)
+}
+
+// CheckRead is [Check] for [PermissionRead].
+func CheckRead(ctx context.Context, policyName string, resource Resource) error {
+	return Check(ctx, policyName, resource, PermissionRead)
+}
+
+// CheckWrite is [Check] for [PermissionWrite].
+func CheckWrite(ctx context.Context, policyName string, resource Resource) error {
+	return Check(ctx, policyName, resource, PermissionWrite)
+}
+
+// CheckRelate is [Check] for [PermissionRelate].
+func CheckRelate(ctx context.Context, policyName string, resource Resource) error {
+	return Check(ctx, policyName, resource, PermissionRelate)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck_UnregisteredPolicyIsUngated(t *testing.T) {
+	err := Check(context.Background(), "no-such-policy", Resource{Scope: ScopeCollection, Collection: "Author"}, PermissionRead)
+	assert.NoError(t, err)
+}
+
+func TestCheck_PublicRuleGrantsAnyActor(t *testing.T) {
+	policyName := "TestCheck_PublicRuleGrantsAnyActor"
+	RegisterPolicy(Policy{
+		Name: policyName,
+		Rules: []Rule{
+			{Scope: ScopeCollection, Collection: "Author", Permission: PermissionRead},
+		},
+	})
+
+	err := Check(context.Background(), policyName, Resource{Scope: ScopeCollection, Collection: "Author"}, PermissionRead)
+	assert.NoError(t, err)
+
+	ctx := WithActor(context.Background(), Actor{ID: "someone"})
+	err = Check(ctx, policyName, Resource{Scope: ScopeCollection, Collection: "Author"}, PermissionRead)
+	assert.NoError(t, err)
+}
+
+func TestCheck_NoMatchingRuleIsDenied(t *testing.T) {
+	policyName := "TestCheck_NoMatchingRuleIsDenied"
+	RegisterPolicy(Policy{
+		Name: policyName,
+		Rules: []Rule{
+			{Scope: ScopeCollection, Collection: "Author", Permission: PermissionRead},
+		},
+	})
+
+	err := Check(context.Background(), policyName, Resource{Scope: ScopeCollection, Collection: "Book"}, PermissionRead)
+	require.Error(t, err)
+	assert.EqualError(
+		t,
+		err,
+		NewErrPermissionDenied(Actor{}, PermissionRead, Resource{Scope: ScopeCollection, Collection: "Book"}).Error(),
+	)
+}
+
+func TestCheck_RegisteredPolicyDeniesUnlistedPermission(t *testing.T) {
+	policyName := "TestCheck_RegisteredPolicyDeniesUnlistedPermission"
+	RegisterPolicy(Policy{
+		Name: policyName,
+		Rules: []Rule{
+			{Scope: ScopeCollection, Collection: "Author", Permission: PermissionRead},
+		},
+	})
+
+	err := CheckWrite(context.Background(), policyName, Resource{Scope: ScopeCollection, Collection: "Author"})
+	assert.Error(t, err)
+}
+
+func TestCheck_ActorScopedRuleOnlyGrantsListedActors(t *testing.T) {
+	policyName := "TestCheck_ActorScopedRuleOnlyGrantsListedActors"
+	RegisterPolicy(Policy{
+		Name: policyName,
+		Rules: []Rule{
+			{
+				Scope:      ScopeDocument,
+				Collection: "Author",
+				DocID:      "bae-1",
+				Permission: PermissionRead,
+				Actors:     []string{"allowed-actor"},
+			},
+		},
+	})
+
+	resource := Resource{Scope: ScopeDocument, Collection: "Author", DocID: "bae-1"}
+
+	err := CheckRead(context.Background(), policyName, resource)
+	assert.Error(t, err, "anonymous actor should not match an actor-scoped rule")
+
+	ctx := WithActor(context.Background(), Actor{ID: "someone-else"})
+	err = CheckRead(ctx, policyName, resource)
+	assert.Error(t, err, "an actor not named in Actors should not match")
+
+	ctx = WithActor(context.Background(), Actor{ID: "allowed-actor"})
+	err = CheckRead(ctx, policyName, resource)
+	assert.NoError(t, err)
+}
+
+func TestCheck_FieldScopedRuleDoesNotMatchDocumentScope(t *testing.T) {
+	policyName := "TestCheck_FieldScopedRuleDoesNotMatchDocumentScope"
+	RegisterPolicy(Policy{
+		Name: policyName,
+		Rules: []Rule{
+			{Scope: ScopeField, Collection: "Author", Field: "ssn", Permission: PermissionRead},
+		},
+	})
+
+	err := CheckRead(context.Background(), policyName, Resource{Scope: ScopeDocument, Collection: "Author", DocID: "bae-1"})
+	assert.Error(t, err)
+
+	err = CheckRead(
+		context.Background(),
+		policyName,
+		Resource{Scope: ScopeField, Collection: "Author", Field: "ssn"},
+	)
+	assert.NoError(t, err)
+}
+
+func TestCheckRelate_UsesPermissionRelate(t *testing.T) {
+	policyName := "TestCheckRelate_UsesPermissionRelate"
+	RegisterPolicy(Policy{
+		Name: policyName,
+		Rules: []Rule{
+			{Scope: ScopeDocument, Collection: "Author", Permission: PermissionRelate},
+		},
+	})
+
+	err := CheckRelate(context.Background(), policyName, Resource{Scope: ScopeDocument, Collection: "Author", DocID: "bae-1"})
+	assert.NoError(t, err)
+
+	err = CheckWrite(context.Background(), policyName, Resource{Scope: ScopeDocument, Collection: "Author", DocID: "bae-1"})
+	assert.Error(t, err, "a rule granting relate should not also grant write")
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"fmt"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const errPermissionDenied string = "acp: permission denied"
+
+// withFields appends the given key/value pairs to msg as "msg. K1: v1, K2: v2, ...", in the order
+// given - the shape every error constructor in this file uses to keep its message
+// machine-greppable without each one hand-rolling its own fmt.Sprintf.
+func withFields(msg string, kvs ...any) string {
+	for i := 0; i+1 < len(kvs); i += 2 {
+		sep := ". "
+		if i > 0 {
+			sep = ", "
+		}
+		msg = fmt.Sprintf("%s%s%s: %v", msg, sep, kvs[i], kvs[i+1])
+	}
+	return msg
+}
+
+// NewErrPermissionDenied returns a new error indicating that actor does not hold permission on
+// resource under the policy that was evaluated. actor.ID is reported as "<anonymous>" for the
+// zero Actor.
+func NewErrPermissionDenied(actor Actor, permission Permission, resource Resource) error {
+	actorID := actor.ID
+	if actorID == "" {
+		actorID = "<anonymous>"
+	}
+
+	return errors.New(withFields(
+		errPermissionDenied,
+		"Actor", actorID,
+		"Permission", permission,
+		"Scope", resource.Scope,
+		"Collection", resource.Collection,
+		"DocID", resource.DocID,
+		"Field", resource.Field,
+	))
+}
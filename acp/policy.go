@@ -0,0 +1,128 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import "sync"
+
+// DefaultPolicyName is the policy name [client.DB]'s own schema and snapshot operations are
+// gated under, in the absence of any finer-grained wiring choosing a different name per
+// collection or request.
+const DefaultPolicyName = "default"
+
+// Scope identifies the granularity a [Rule] applies to.
+type Scope string
+
+const (
+	// ScopeCollection matches a Rule against every document of a named collection, and against
+	// schema-level operations (AddSchema, PatchSchema, MergeSchema) affecting it.
+	ScopeCollection Scope = "collection"
+	// ScopeDocument matches a Rule against a single named document within a collection.
+	ScopeDocument Scope = "document"
+	// ScopeField matches a Rule against a single named field of a collection or document.
+	ScopeField Scope = "field"
+)
+
+// Permission identifies the kind of access a [Rule] grants.
+type Permission string
+
+const (
+	// PermissionRead grants an actor visibility of matching data in query results.
+	PermissionRead Permission = "read"
+	// PermissionWrite grants an actor the ability to create, update, or delete matching data,
+	// whether initiated locally or arriving as a replicated CRDT update over P2P.
+	PermissionWrite Permission = "write"
+	// PermissionRelate grants an actor the ability to create or remove a relation (e.g. a
+	// document-to-actor grant) scoped to matching data.
+	PermissionRelate Permission = "relate"
+)
+
+// Resource identifies what a policy check is being evaluated against. Fields not relevant to
+// Scope are left zero.
+type Resource struct {
+	Scope Scope
+	// Collection is the collection name. Relevant to ScopeCollection, ScopeDocument, and
+	// ScopeField.
+	Collection string
+	// DocID is the document's identifier. Relevant to ScopeDocument and ScopeField.
+	DocID string
+	// Field is the field name. Relevant to ScopeField.
+	Field string
+}
+
+// Rule grants Permission on resources of exactly its Scope that match Collection/DocID/Field (a
+// zero value in any of those three matches anything) to the actors named in Actors.
+type Rule struct {
+	Scope      Scope
+	Collection string
+	DocID      string
+	Field      string
+	Permission Permission
+	// Actors restricts the rule to these actor IDs. A nil or empty slice grants the permission
+	// publicly, to every actor - including the anonymous one.
+	Actors []string
+}
+
+// matches reports whether r grants permission on resource to actor.
+func (r Rule) matches(resource Resource, permission Permission, actor Actor) bool {
+	if r.Permission != permission || r.Scope != resource.Scope {
+		return false
+	}
+	if r.Collection != "" && r.Collection != resource.Collection {
+		return false
+	}
+	if r.DocID != "" && r.DocID != resource.DocID {
+		return false
+	}
+	if r.Field != "" && r.Field != resource.Field {
+		return false
+	}
+
+	if len(r.Actors) == 0 {
+		return true
+	}
+	for _, id := range r.Actors {
+		if id == actor.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Policy is a named, ordered set of Rules, evaluated as "permit if any rule matches" - this
+// package has no explicit deny rule; a policy that should default-deny a permission/resource
+// simply omits a public (Actors-empty) rule granting it.
+type Policy struct {
+	Name  string
+	Rules []Rule
+}
+
+// registryMu guards registry.
+var registryMu sync.Mutex
+
+// registry holds every Policy registered via RegisterPolicy, keyed by name - mirroring the
+// registration pattern [datastore.RegisterBackend] already uses for pluggable backends.
+var registry = map[string]Policy{}
+
+// RegisterPolicy installs policy under its Name, replacing any previously registered policy of
+// the same name.
+func RegisterPolicy(policy Policy) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[policy.Name] = policy
+}
+
+// lookupPolicy returns the registered policy called name.
+func lookupPolicy(name string) (Policy, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	policy, ok := registry[name]
+	return policy, ok
+}
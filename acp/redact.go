@@ -0,0 +1,65 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package acp
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+// RedactRows walks rows (as returned in a [client.GQLResult]'s Data) and, for each field named in
+// fields, nulls it out wherever the actor attached to ctx is denied [PermissionRead] on it under
+// policyName - scoped per-row by docIDField, the field within each row holding the document's
+// _docID (or "" if rows aren't keyed by one).
+//
+// This lets a single unauthorized field fail closed without failing the request that asked for
+// it: the caller gets every field it may see, a null in place of each it may not, and a
+// [client.RedactedField] entry recording why, ready to be assigned to [client.GQLResult.Redacted].
+//
+// Nothing in this source snapshot calls RedactRows yet - there is no concrete request-execution
+// pipeline here to build a [client.GQLResult] to call it from. It is scaffolding for that call
+// site, not a feature wired up end-to-end today.
+func RedactRows(
+	ctx context.Context,
+	policyName string,
+	collection string,
+	docIDField string,
+	rows []map[string]any,
+	fields []string,
+) []client.RedactedField {
+	var redacted []client.RedactedField
+
+	for rowIndex, row := range rows {
+		docID, _ := row[docIDField].(string)
+
+		for _, field := range fields {
+			if _, present := row[field]; !present {
+				continue
+			}
+
+			resource := Resource{Scope: ScopeField, Collection: collection, DocID: docID, Field: field}
+			err := CheckRead(ctx, policyName, resource)
+			if err == nil {
+				continue
+			}
+
+			row[field] = nil
+			redacted = append(redacted, client.RedactedField{
+				Path:   []string{strconv.Itoa(rowIndex), field},
+				Reason: err.Error(),
+			})
+		}
+	}
+
+	return redacted
+}
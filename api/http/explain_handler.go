@@ -0,0 +1,125 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/planner"
+	"github.com/sourcenetwork/defradb/request/graphql/schema/types"
+)
+
+// explainRequestArg is the name of the `explain` query field's sole argument: the GQL request
+// (with an `@explain` directive) to run and report on.
+const explainRequestArg = "request"
+
+// newExplainSchema builds the typed GraphQL schema served at `/api/v0/explain`: a single `explain`
+// query field returning a [types.PlanNodeInterface] tree, giving clients a stable, introspectable
+// API instead of the untyped JSON an `@explain` directive embeds in a regular query response.
+func newExplainSchema(db client.DB) (gql.Schema, error) {
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "ExplainQuery",
+		Fields: gql.Fields{
+			"explain": &gql.Field{
+				Type: types.PlanNodeInterface,
+				Args: gql.FieldConfigArgument{
+					explainRequestArg: &gql.ArgumentConfig{
+						Type: gql.NewNonNull(gql.String),
+					},
+				},
+				Resolve: resolveExplainQuery(db),
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{
+		Query: query,
+		Types: types.PlanNodeObjectTypes(),
+	})
+}
+
+// resolveExplainQuery executes the requested GQL request against db and converts its embedded
+// `@explain` result into a typed [planner.ExplainGraphNode] tree.
+func resolveExplainQuery(db client.DB) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (any, error) {
+		requestStr, _ := p.Args[explainRequestArg].(string)
+
+		result := db.ExecRequest(p.Context, requestStr)
+		if len(result.GQL.Errors) > 0 {
+			return nil, result.GQL.Errors[0]
+		}
+
+		data, isList := result.GQL.Data.([]map[string]any)
+		if !isList || len(data) == 0 {
+			return nil, nil
+		}
+
+		graph, hasExplain := data[0][types.ExplainLabel].(map[string]any)
+		if !hasExplain {
+			return nil, nil
+		}
+
+		// BuildExplainGraph wraps graph in a synthetic "explain" root that exists only to let
+		// Children() walk the top-level plan node(s) - it isn't itself a registered plan node
+		// type, so returning it directly would leave ResolveType with nothing to resolve it to.
+		// Unwrap to the real root plan node before returning it from the resolver.
+		rootChildren := planner.BuildExplainGraph(graph).Children()
+		if len(rootChildren) == 0 {
+			return nil, nil
+		}
+
+		return rootChildren[0], nil
+	}
+}
+
+// ExplainHandler serves a dedicated `/api/v0/explain` GraphQL endpoint backed by
+// [types.PlanNodeInterface], so that `@explain` results can be queried and introspected as a
+// typed graph rather than parsed out of a regular request's JSON response.
+type ExplainHandler struct {
+	schema gql.Schema
+}
+
+// NewExplainHandler builds an [ExplainHandler] for the given database.
+func NewExplainHandler(db client.DB) (*ExplainHandler, error) {
+	schema, err := newExplainSchema(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExplainHandler{schema: schema}, nil
+}
+
+type explainHTTPRequest struct {
+	Query string `json:"query"`
+}
+
+func (h *ExplainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req explainHTTPRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := withTraceContext(r.Context(), r)
+
+	result := gql.Do(gql.Params{
+		Schema:        h.schema,
+		RequestString: req.Query,
+		Context:       ctx,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
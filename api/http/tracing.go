@@ -0,0 +1,61 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracingConfig configures the OpenTelemetry span exporter used to publish explain-graph spans
+// for `@explain(type: execute)` requests served over this API.
+type TracingConfig struct {
+	// OTLPEndpoint is the address of the OTLP collector (e.g. Jaeger, Tempo) that explain spans
+	// are exported to. Tracing is disabled if this is left empty.
+	OTLPEndpoint string
+}
+
+// configureTracing installs a global OpenTelemetry tracer provider that exports spans to the
+// configured OTLP collector endpoint, and returns a shutdown function that should be called when
+// the server stops. It is a no-op (returning a no-op shutdown function) if cfg.OTLPEndpoint is
+// empty.
+func configureTracing(ctx context.Context, cfg TracingConfig) (func(context.Context) error, error) {
+	if cfg.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracegrpc.New(
+		ctx,
+		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// withTraceContext extracts an incoming W3C `traceparent` header (if any) and returns a context
+// carrying the remote span, so that any explain-graph spans emitted while handling the request
+// are correctly parented under the caller's trace.
+func withTraceContext(ctx context.Context, r *http.Request) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// ValidateUpdateCollection checks that after is a valid evolution of before, the persisted
+// CollectionDescription it is about to replace - see [Store.UpdateCollectionTxn].
+//
+// It rejects two kinds of change: removing a field that one of before's Indexes still covers
+// (there is no migration step yet able to carry that index's existing entries forward onto a
+// schema that no longer has the field), and changing an existing field's Kind to anything other
+// than its current value (no Kind conversion is currently known to be safe to apply over
+// already-persisted data).
+func ValidateUpdateCollection(before CollectionDescription, after CollectionDescription) error {
+	beforeFieldsByName := fieldDescriptionsByName(before.Schema.Fields)
+	afterFieldsByName := fieldDescriptionsByName(after.Schema.Fields)
+
+	for name, beforeField := range beforeFieldsByName {
+		afterField, stillExists := afterFieldsByName[name]
+		if !stillExists {
+			if indexCovers(before.Schema.Indexes, name) {
+				return NewErrCannotRemoveIndexedField(name)
+			}
+			continue
+		}
+
+		if afterField.Kind != beforeField.Kind {
+			return NewErrCannotChangeFieldKind(name, beforeField.Kind, afterField.Kind)
+		}
+	}
+
+	return nil
+}
+
+func fieldDescriptionsByName(fields []FieldDescription) map[string]FieldDescription {
+	byName := make(map[string]FieldDescription, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+	return byName
+}
+
+// indexCovers returns true if any of the given indexes includes fieldName among its fields.
+func indexCovers(indexes []IndexDescription, fieldName string) bool {
+	for _, index := range indexes {
+		for _, indexedField := range index.Fields {
+			if indexedField.Name == fieldName {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUpdateCollection(t *testing.T) {
+	tests := []struct {
+		name      string
+		before    CollectionDescription
+		after     CollectionDescription
+		expectErr error
+	}{
+		{
+			name: "unrelated field added",
+			before: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{{Name: "name", Kind: FieldKind_NILLABLE_STRING}},
+				},
+			},
+			after: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{
+						{Name: "name", Kind: FieldKind_NILLABLE_STRING},
+						{Name: "age", Kind: FieldKind_NILLABLE_INT},
+					},
+				},
+			},
+		},
+		{
+			name: "unindexed field removed",
+			before: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{
+						{Name: "name", Kind: FieldKind_NILLABLE_STRING},
+						{Name: "notes", Kind: FieldKind_NILLABLE_STRING},
+					},
+				},
+			},
+			after: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{{Name: "name", Kind: FieldKind_NILLABLE_STRING}},
+				},
+			},
+		},
+		{
+			name: "indexed field removed is rejected",
+			before: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{
+						{Name: "name", Kind: FieldKind_NILLABLE_STRING},
+						{Name: "age", Kind: FieldKind_NILLABLE_INT},
+					},
+					Indexes: []IndexDescription{
+						{Name: "byAge", Fields: []IndexedFieldDescription{{Name: "age"}}},
+					},
+				},
+			},
+			after: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields:  []FieldDescription{{Name: "name", Kind: FieldKind_NILLABLE_STRING}},
+					Indexes: []IndexDescription{{Name: "byAge", Fields: []IndexedFieldDescription{{Name: "age"}}}},
+				},
+			},
+			expectErr: NewErrCannotRemoveIndexedField("age"),
+		},
+		{
+			name: "removing one field of a composite index is still rejected",
+			before: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{
+						{Name: "firstName", Kind: FieldKind_NILLABLE_STRING},
+						{Name: "lastName", Kind: FieldKind_NILLABLE_STRING},
+					},
+					Indexes: []IndexDescription{
+						{
+							Name: "byFullName",
+							Fields: []IndexedFieldDescription{
+								{Name: "firstName"},
+								{Name: "lastName"},
+							},
+						},
+					},
+				},
+			},
+			after: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{{Name: "lastName", Kind: FieldKind_NILLABLE_STRING}},
+					Indexes: []IndexDescription{
+						{
+							Name: "byFullName",
+							Fields: []IndexedFieldDescription{
+								{Name: "firstName"},
+								{Name: "lastName"},
+							},
+						},
+					},
+				},
+			},
+			expectErr: NewErrCannotRemoveIndexedField("firstName"),
+		},
+		{
+			name: "field kind changed is rejected",
+			before: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{{Name: "age", Kind: FieldKind_NILLABLE_INT}},
+				},
+			},
+			after: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields: []FieldDescription{{Name: "age", Kind: FieldKind_NILLABLE_STRING}},
+				},
+			},
+			expectErr: NewErrCannotChangeFieldKind("age", FieldKind_NILLABLE_INT, FieldKind_NILLABLE_STRING),
+		},
+		{
+			name: "field kind unchanged on an indexed field is fine",
+			before: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields:  []FieldDescription{{Name: "age", Kind: FieldKind_NILLABLE_INT}},
+					Indexes: []IndexDescription{{Name: "byAge", Fields: []IndexedFieldDescription{{Name: "age"}}}},
+				},
+			},
+			after: CollectionDescription{
+				Schema: SchemaDescription{
+					Fields:  []FieldDescription{{Name: "age", Kind: FieldKind_NILLABLE_INT}},
+					Indexes: []IndexDescription{{Name: "byAge", Fields: []IndexedFieldDescription{{Name: "age"}}}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateUpdateCollection(test.before, test.after)
+			if test.expectErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.EqualError(t, err, test.expectErr.Error())
+		})
+	}
+}
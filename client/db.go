@@ -12,6 +12,7 @@ package client
 
 import (
 	"context"
+	"io"
 
 	blockstore "github.com/ipfs/boxo/blockstore"
 
@@ -38,6 +39,13 @@ type DB interface {
 	// can safely operate on it concurrently.
 	NewConcurrentTxn(context.Context, bool) (datastore.Txn, error)
 
+	// NewBatch returns a new batch-mode [datastore.Txn] (see [datastore.NewBatchFrom]) on the root
+	// store, for bulk-ingest callers that want to push many writes through a single flush without
+	// paying the cost of MVCC conflict detection on each one.
+	//
+	// Returns [datastore.ErrBatchingNotSupported] if the root store does not support batching.
+	NewBatch(context.Context) (datastore.Txn, error)
+
 	// WithTxn returns a new [client.Store] that respects the given transaction.
 	WithTxn(datastore.Txn) Store
 
@@ -83,12 +91,16 @@ type Store interface {
 	// P2P holds the P2P related methods that must be implemented by the database.
 	P2P
 
-	// AddSchema takes the provided GQL schema in SDL format, and applies it to the [Store],
-	// creating the necessary collections, request types, etc.
+	// AddSchema takes the provided GQL schema(s) in SDL format, and applies them to the [Store] as a
+	// single unit, creating the necessary collections, request types, etc.
+	//
+	// Multiple SDL sources may be given, either as separate arguments or as a single source
+	// containing `# --- file: name.graphql ---` delimited segments - in both cases they are parsed
+	// together so that types defined in one source may reference types defined in another.
 	//
 	// All schema types provided must not exist prior to calling this, and they may not reference existing
 	// types previously defined.
-	AddSchema(context.Context, string) ([]CollectionDescription, error)
+	AddSchema(context.Context, ...string) ([]CollectionDescription, error)
 
 	// PatchSchema takes the given JSON patch string and applies it to the set of CollectionDescriptions
 	// present in the database.
@@ -106,6 +118,55 @@ type Store interface {
 	// [FieldKindStringToEnumMapping].
 	PatchSchema(context.Context, string) error
 
+	// MergeSchema takes the given strategic merge patch document and applies it to the set of
+	// CollectionDescriptions present in the [Store].
+	//
+	// Unlike [PatchSchema], which requires RFC 6902 JSON Patch operations addressed by exact array
+	// index, this accepts a partial CollectionDescription document keyed by collection name, with
+	// keyed arrays (Schema.Fields and Indexes, both keyed by Name) merged by that key rather than
+	// by index.
+	//
+	// It will also update the GQL types used by the query system. It will error and not apply any
+	// of the requested, valid updates should the net result of the merge result in an invalid state.
+	//
+	// Field [FieldKind] values may be provided in either their raw integer form, or as string as per
+	// [FieldKindStringToEnumMapping].
+	MergeSchema(context.Context, string) error
+
+	// PatchSchemaDryRun runs the same validation pipeline as [PatchSchema] - substituting
+	// [FieldKind] string values, applying the patch, decoding, and revalidating against
+	// [updateCollection] - inside a throwaway transaction that is always rolled back, returning a
+	// [SchemaDiff] describing the collections, fields, and schema version IDs that would result,
+	// without persisting any of it.
+	PatchSchemaDryRun(context.Context, string) (SchemaDiff, error)
+
+	// CreateCollection creates a new collection from the given, fully-formed
+	// CollectionDescription, opening and committing its own transaction.
+	//
+	// Unlike [AddSchema], which derives a CollectionDescription by parsing SDL, this is for
+	// callers - migration tools, code generators - that already build the description
+	// programmatically and want it persisted as-is. desc must not describe a collection that
+	// already exists.
+	CreateCollection(context.Context, CollectionDescription) (Collection, error)
+
+	// CreateCollectionTxn is [CreateCollection], run against txn rather than a transaction opened
+	// and committed internally.
+	CreateCollectionTxn(context.Context, datastore.Txn, CollectionDescription) (Collection, error)
+
+	// UpdateCollectionTxn persists desc over the collection it names, after validating the change
+	// via [ValidateUpdateCollection], and updates the GQL types used by the query system as part
+	// of the same transaction.
+	UpdateCollectionTxn(context.Context, datastore.Txn, CollectionDescription) (Collection, error)
+
+	// SubscribeSchemaChanges returns a channel that receives a [SchemaChangeEvent] whenever
+	// [AddSchema], [PatchSchema], or [MergeSchema] successfully commits a change to the set of
+	// persisted CollectionDescriptions.
+	//
+	// This lets GraphQL gateways and client caches invalidate generated query types when a live
+	// DefraDB instance mutates its schema, without having to poll for changes. Subscribers that
+	// fall behind will have their oldest buffered event dropped to make room for the newest.
+	SubscribeSchemaChanges(context.Context) (<-chan SchemaChangeEvent, error)
+
 	// GetCollectionByName attempts to retrieve a collection matching the given name.
 	//
 	// If no matching collection is found an error will be returned.
@@ -126,7 +187,29 @@ type Store interface {
 	GetAllCollections(context.Context) ([]Collection, error)
 
 	// ExecRequest executes the given GQL request against the [Store].
+	//
+	// An implementation MAY consult the actor attached to the context (see acp.WithActor) against
+	// the access-control policy governing each collection the request touches: a denied read
+	// should redact the offending field (see [RedactedField], and acp.RedactRows which builds
+	// that list from a set of result rows) rather than failing the request outright, while a
+	// denied write or relate should fail it. There is currently no concrete implementation of
+	// this method in this chunk to confirm that against - acp.RedactRows and [RedactedField] are
+	// scaffolding for whatever request-execution pipeline eventually builds a [GQLResult], not a
+	// contract already being honoured end-to-end today.
 	ExecRequest(context.Context, string) *RequestResult
+
+	// Snapshot writes a portable, backend-independent copy of every key/value pair held by this
+	// [Store] to w, as a simple length-prefixed stream.
+	//
+	// Unlike copying a backend's own data directory, the resulting snapshot may be loaded via
+	// [Restore] into a [Store] running on a different datastore backend - this is primarily
+	// useful to the change detector and to tests that need to carry state across a backend or
+	// branch boundary without depending on on-disk format compatibility.
+	Snapshot(context.Context, io.Writer) error
+
+	// Restore loads a snapshot produced by [Snapshot] into this [Store], overwriting any existing
+	// keys it contains.
+	Restore(context.Context, io.Reader) error
 }
 
 // GQLResult represents the immediate results of a GQL request.
@@ -143,6 +226,25 @@ type GQLResult struct {
 	//
 	// It will be nil if any errors were raised during execution.
 	Data any `json:"data"`
+
+	// Redacted lists the fields of Data, if any, that were nulled out because the request's
+	// actor lacked permission to read them, rather than failing the request outright - see
+	// acp.RedactRows, which builds this slice. No current [ExecRequest] implementation populates
+	// this field yet; it exists so a future request-execution pipeline has somewhere to put that
+	// result.
+	Redacted []RedactedField `json:"redacted,omitempty"`
+}
+
+// RedactedField identifies a single field within [GQLResult.Data] that was nulled out because
+// the request's actor lacked permission to read it, so a client can distinguish "no data" from
+// "this field exists but you may not see it" without the whole request failing.
+type RedactedField struct {
+	// Path is the field's location within Data, e.g. []string{"0", "author", "ssn"} for the
+	// "ssn" field of the "author" relation on the first returned row.
+	Path []string `json:"path"`
+
+	// Reason is the access-control error explaining why the field was redacted.
+	Reason string `json:"reason"`
 }
 
 // RequestResult represents the results of a GQL request.
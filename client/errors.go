@@ -0,0 +1,54 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const errFieldValueConstraintViolation string = "field value violates constraint"
+const errCannotRemoveIndexedField string = "cannot remove a field that is still covered by an index"
+const errCannotChangeFieldKind string = "cannot change an existing field's kind"
+
+// withFields appends the given key/value pairs to msg as "msg. K1: v1, K2: v2, ...", in the order
+// given - the shape every error constructor in this file uses to keep its message
+// machine-greppable without each one hand-rolling its own fmt.Sprintf.
+func withFields(msg string, kvs ...any) string {
+	for i := 0; i+1 < len(kvs); i += 2 {
+		sep := ". "
+		if i > 0 {
+			sep = ", "
+		}
+		msg = fmt.Sprintf("%s%s%s: %v", msg, sep, kvs[i], kvs[i+1])
+	}
+	return msg
+}
+
+// NewErrFieldValueConstraintViolation returns a new error indicating that the given field's
+// value violates the named constraint rule.
+func NewErrFieldValueConstraintViolation(fieldName string, rule string, ruleValue any) error {
+	return errors.New(withFields(errFieldValueConstraintViolation, "Field", fieldName, "Rule", rule, "Value", ruleValue))
+}
+
+// NewErrCannotRemoveIndexedField returns a new error indicating that the named field cannot be
+// removed from a CollectionDescription because an index still covers it - see
+// [ValidateUpdateCollection].
+func NewErrCannotRemoveIndexedField(fieldName string) error {
+	return errors.New(withFields(errCannotRemoveIndexedField, "Field", fieldName))
+}
+
+// NewErrCannotChangeFieldKind returns a new error indicating that the named field's Kind cannot
+// be changed from its current value - see [ValidateUpdateCollection].
+func NewErrCannotChangeFieldKind(fieldName string, from FieldKind, to FieldKind) error {
+	return errors.New(withFields(errCannotChangeFieldKind, "Field", fieldName, "From", from, "To", to))
+}
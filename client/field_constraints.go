@@ -0,0 +1,106 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import (
+	"regexp"
+
+	"github.com/sourcenetwork/immutable"
+)
+
+// FieldConstraints holds the value-level validation rules declared for a field via the
+// `@constraint` directive, folded in by `parser.ParseSDL` from [FieldDescription.Constraints].
+type FieldConstraints struct {
+	// Min is the minimum allowed numeric value (inclusive), if set.
+	Min immutable.Option[float64]
+	// Max is the maximum allowed numeric value (inclusive), if set.
+	Max immutable.Option[float64]
+	// MinLength is the minimum allowed length of a string value, if set.
+	MinLength immutable.Option[int]
+	// MaxLength is the maximum allowed length of a string value, if set.
+	MaxLength immutable.Option[int]
+	// Pattern is a regular expression that a string value must match, if set.
+	Pattern immutable.Option[string]
+	// OneOf is the exhaustive list of values that are permitted, if set.
+	OneOf []string
+	// Format is a well-known format (e.g. email, url, uuid) that a string value must satisfy,
+	// if set.
+	Format immutable.Option[string]
+}
+
+// Validate checks the given value against these constraints, returning a
+// [NewErrFieldValueConstraintViolation] describing the first rule that the value breaks, or nil
+// if the value satisfies all of them.
+//
+// Nothing in this source snapshot calls Validate yet - there is no concrete document create/
+// update path here to call it from (see the note on [Store.ExecRequest]). It is scaffolding for
+// that call site, not a mutation guarantee already being honoured end-to-end today.
+func (c FieldConstraints) Validate(fieldName string, value any) error {
+	switch typedValue := value.(type) {
+	case float64:
+		if c.Min.HasValue() && typedValue < c.Min.Value() {
+			return NewErrFieldValueConstraintViolation(fieldName, "min", c.Min.Value())
+		}
+		if c.Max.HasValue() && typedValue > c.Max.Value() {
+			return NewErrFieldValueConstraintViolation(fieldName, "max", c.Max.Value())
+		}
+
+	case string:
+		if c.MinLength.HasValue() && len(typedValue) < c.MinLength.Value() {
+			return NewErrFieldValueConstraintViolation(fieldName, "minLength", c.MinLength.Value())
+		}
+		if c.MaxLength.HasValue() && len(typedValue) > c.MaxLength.Value() {
+			return NewErrFieldValueConstraintViolation(fieldName, "maxLength", c.MaxLength.Value())
+		}
+		if c.Pattern.HasValue() {
+			matched, err := regexp.MatchString(c.Pattern.Value(), typedValue)
+			if err != nil {
+				return err
+			}
+			if !matched {
+				return NewErrFieldValueConstraintViolation(fieldName, "pattern", c.Pattern.Value())
+			}
+		}
+		if len(c.OneOf) > 0 && !contains(c.OneOf, typedValue) {
+			return NewErrFieldValueConstraintViolation(fieldName, "oneOf", c.OneOf)
+		}
+		if c.Format.HasValue() && !matchesFormat(c.Format.Value(), typedValue) {
+			return NewErrFieldValueConstraintViolation(fieldName, "format", c.Format.Value())
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+var formatPatterns = map[string]*regexp.Regexp{
+	"email": regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`),
+	"url":   regexp.MustCompile(`^https?://\S+$`),
+	"uuid":  regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+}
+
+// matchesFormat returns true if the given value satisfies the named well-known format, or if the
+// format is not recognised (in which case there is nothing to enforce).
+func matchesFormat(format string, value string) bool {
+	pattern, isKnown := formatPatterns[format]
+	if !isKnown {
+		return true
+	}
+	return pattern.MatchString(value)
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+// SchemaChangeType describes the nature of a change observed between two versions of a
+// CollectionDescription (or one of its sub-elements).
+type SchemaChangeType string
+
+const (
+	// SchemaChangeAdded indicates that the element did not exist previously, but exists now.
+	SchemaChangeAdded SchemaChangeType = "added"
+	// SchemaChangeRemoved indicates that the element existed previously, but no longer exists.
+	SchemaChangeRemoved SchemaChangeType = "removed"
+	// SchemaChangeModified indicates that the element exists in both versions, but differs
+	// between them.
+	SchemaChangeModified SchemaChangeType = "modified"
+)
+
+// SchemaDiff describes the changes that a schema patch or merge would make (or has made) to the
+// set of persisted CollectionDescriptions.
+//
+// It is serializable to JSON so that it may be consumed by CLI output or other external tooling.
+type SchemaDiff struct {
+	// Collections contains one entry per collection that was added, removed, or modified.
+	//
+	// Collections whose net result is unchanged are omitted.
+	Collections []CollectionDiff `json:"collections"`
+}
+
+// CollectionDiff describes the change observed for a single collection between two schema
+// versions.
+type CollectionDiff struct {
+	// Name is the name of the affected collection.
+	Name string `json:"name"`
+
+	// ChangeType describes the nature of the change observed for this collection as a whole.
+	ChangeType SchemaChangeType `json:"changeType"`
+
+	// PreviousSchemaVersionID is the schema version ID the collection had before the change, if
+	// it existed before the change.
+	PreviousSchemaVersionID string `json:"previousSchemaVersionID,omitempty"`
+
+	// NewSchemaVersionID is the schema version ID the collection has (or would have) after the
+	// change, if it exists after the change.
+	NewSchemaVersionID string `json:"newSchemaVersionID,omitempty"`
+
+	// Fields contains one entry per field that was added, removed, or whose Kind was modified.
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// FieldDiff describes the change observed for a single field within a collection's schema.
+type FieldDiff struct {
+	// Name is the name of the affected field.
+	Name string `json:"name"`
+
+	// ChangeType describes the nature of the change observed for this field.
+	ChangeType SchemaChangeType `json:"changeType"`
+}
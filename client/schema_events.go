@@ -0,0 +1,23 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package client
+
+import "time"
+
+// SchemaChangeEvent describes a single commit that altered the set of persisted
+// CollectionDescriptions, as yielded by [Store.SubscribeSchemaChanges].
+type SchemaChangeEvent struct {
+	// CommitTime is the time at which the transaction that produced this change was committed.
+	CommitTime time.Time
+
+	// Diff describes the collections, fields, and schema version IDs that changed.
+	Diff SchemaDiff
+}
@@ -0,0 +1,239 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package datastore
+
+import (
+	"context"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const errBatchingNotSupported string = "rootstore does not support batching"
+
+// ErrBatchingNotSupported is returned by [NewBatchFrom] when the given rootstore does not
+// implement [ds.Batching].
+var ErrBatchingNotSupported = errors.New(errBatchingNotSupported)
+
+// NewBatchFrom returns a new [Txn] in batch mode against rootstore, for bulk-ingest callers (such
+// as a CreateDoc loop pushing thousands of documents) that don't need MVCC conflict detection and
+// would otherwise pay its per-write cost needlessly.
+//
+// Unlike [NewTxnFrom], which opens an MVCC transaction, the returned Txn accumulates writes into
+// a [ds.Batch] obtained from rootstore; [Txn.Commit] flushes them in one round-trip via
+// [ds.Batch.Commit] rather than conflict-checking and persisting them one at a time.
+// [Txn.IsBatch] reports true on the result, and [Txn.OnSuccess]/[Txn.OnError] still fire as usual
+// around that single flush.
+//
+// Reads transparently see the batch's own unflushed writes via an in-memory overlay - [ds.Batch]
+// itself offers no way to read back what has been queued onto it. This overlay only intercepts
+// point reads (Get, Has, GetSize): a Query issued against the returned Txn is served straight
+// from rootstore and will not reflect the batch's unflushed writes.
+//
+// Because there is no MVCC to conflict-check, [Txn.ReadSet] and [Txn.WriteSet] are always empty -
+// the transaction-conflict oracle has nothing meaningful to say about a batch.
+//
+// [Txn.Rootstore] returns the same overlay-backed reader/writer the [MultiStore] accessors are
+// built from, so reads issued through it observe the Datastore/Headstore/DAGstore accessors'
+// uncommitted writes within this batch, and vice versa.
+//
+// Returns [ErrBatchingNotSupported] if rootstore does not implement [ds.Batching].
+func NewBatchFrom(ctx context.Context, rootstore ds.Datastore) (Txn, error) {
+	batchingStore, ok := rootstore.(ds.Batching)
+	if !ok {
+		return nil, ErrBatchingNotSupported
+	}
+
+	batch, err := batchingStore.Batch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overlay := &batchOverlayDatastore{
+		Datastore: rootstore,
+		batch:     batch,
+		written:   map[string][]byte{},
+		deleted:   map[string]struct{}{},
+	}
+
+	root := AsDSReaderWriter(overlay)
+	multistore := MultiStoreFrom(root)
+	return &batchTxn{overlay: overlay, root: root, MultiStore: multistore}, nil
+}
+
+// batchOverlayDatastore decorates rootstore with an in-memory overlay of writes queued onto batch
+// but not yet committed, so a read against a key just written within the same batch sees that
+// write rather than whatever rootstore itself currently holds for it.
+type batchOverlayDatastore struct {
+	ds.Datastore
+	batch ds.Batch
+
+	mu      sync.RWMutex
+	written map[string][]byte
+	deleted map[string]struct{}
+}
+
+func (b *batchOverlayDatastore) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	if value, isDeleted, overlaid := b.lookup(key); overlaid {
+		if isDeleted {
+			return nil, ds.ErrNotFound
+		}
+		return value, nil
+	}
+	return b.Datastore.Get(ctx, key)
+}
+
+func (b *batchOverlayDatastore) Has(ctx context.Context, key ds.Key) (bool, error) {
+	if _, isDeleted, overlaid := b.lookup(key); overlaid {
+		return !isDeleted, nil
+	}
+	return b.Datastore.Has(ctx, key)
+}
+
+func (b *batchOverlayDatastore) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	if value, isDeleted, overlaid := b.lookup(key); overlaid {
+		if isDeleted {
+			return 0, ds.ErrNotFound
+		}
+		return len(value), nil
+	}
+	return b.Datastore.GetSize(ctx, key)
+}
+
+// lookup returns the overlay's view of key, if this key has been written or deleted within the
+// batch since it was opened.
+func (b *batchOverlayDatastore) lookup(key ds.Key) (value []byte, isDeleted bool, overlaid bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if _, ok := b.deleted[key.String()]; ok {
+		return nil, true, true
+	}
+	if value, ok := b.written[key.String()]; ok {
+		return value, false, true
+	}
+	return nil, false, false
+}
+
+func (b *batchOverlayDatastore) Put(ctx context.Context, key ds.Key, value []byte) error {
+	if err := b.batch.Put(ctx, key, value); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.deleted, key.String())
+	b.written[key.String()] = value
+	return nil
+}
+
+func (b *batchOverlayDatastore) Delete(ctx context.Context, key ds.Key) error {
+	if err := b.batch.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.written, key.String())
+	b.deleted[key.String()] = struct{}{}
+	return nil
+}
+
+func (b *batchOverlayDatastore) Sync(ctx context.Context, prefix ds.Key) error {
+	return b.batch.Commit(ctx)
+}
+
+// batchTxn is the [Txn] returned by [NewBatchFrom] - see its doc comment for the batch-mode
+// semantics.
+type batchTxn struct {
+	overlay *batchOverlayDatastore
+	root    DSReaderWriter
+	MultiStore
+
+	mu         sync.Mutex
+	successFns []func()
+	errorFns   []func()
+}
+
+var _ Txn = (*batchTxn)(nil)
+
+// IsBatch reports true: this Txn accumulates writes into a [ds.Batch] rather than an MVCC
+// transaction - see [NewBatchFrom].
+func (t *batchTxn) IsBatch() bool {
+	return true
+}
+
+// Rootstore returns the overlay-backed reader/writer that this transaction's [MultiStore]
+// accessors were built from - see [Txn.Rootstore]. Like those accessors, it only sees the batch's
+// own unflushed writes through the point-read overlay described on [NewBatchFrom]; a Query issued
+// against it is not overlay-aware.
+func (t *batchTxn) Rootstore() DSReaderWriter {
+	return t.root
+}
+
+// Commit flushes the accumulated batch via [ds.Batch.Commit]. There is no MVCC conflict to
+// detect, so - unlike the non-batch [txn.Commit] - this cannot fail with a conflict error; it can
+// still fail for the usual I/O reasons the underlying store might return.
+func (t *batchTxn) Commit(ctx context.Context) error {
+	if err := t.overlay.batch.Commit(ctx); err != nil {
+		t.runErrorFns(ctx)
+		return err
+	}
+	t.runSuccessFns(ctx)
+	return nil
+}
+
+// Discard drops the batch's queued-but-uncommitted writes. [ds.Batch] has no explicit
+// rollback of its own; simply never calling Commit has the same effect, since nothing queued
+// onto it reaches rootstore until then.
+func (t *batchTxn) Discard(ctx context.Context) {}
+
+func (t *batchTxn) OnSuccess(fn func()) {
+	if fn == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.successFns = append(t.successFns, fn)
+}
+
+func (t *batchTxn) OnError(fn func()) {
+	if fn == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errorFns = append(t.errorFns, fn)
+}
+
+func (t *batchTxn) runSuccessFns(ctx context.Context) {
+	for _, fn := range t.successFns {
+		fn()
+	}
+}
+
+func (t *batchTxn) runErrorFns(ctx context.Context) {
+	for _, fn := range t.errorFns {
+		fn()
+	}
+}
+
+// ReadSet always returns nil in batch mode - see [NewBatchFrom].
+func (t *batchTxn) ReadSet() []ds.Key {
+	return nil
+}
+
+// WriteSet always returns nil in batch mode - see [NewBatchFrom].
+func (t *batchTxn) WriteSet() []ds.Key {
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package faultds decorates any [datastore.RootStore] with configurable, seeded fault injection -
+// latency, transient errors, partial-write crashes, and iterator early termination - so that the
+// action-driven integration suite can be replayed under jitter/failure. Each seed produces a
+// reproducible failure schedule, so a failing run can be triaged deterministically.
+package faultds
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// Config controls the probability (0-1) of each fault kind that [Datastore] may inject on a
+// given operation.
+type Config struct {
+	// ErrorProbability is the chance that a Put/Get/Delete/Commit call returns a transient error
+	// instead of being forwarded to the wrapped store.
+	ErrorProbability float64
+
+	// LatencyProbability is the chance that a call is delayed by a random duration up to
+	// MaxLatency before being forwarded.
+	LatencyProbability float64
+
+	// MaxLatency bounds the latency injected per LatencyProbability.
+	MaxLatency time.Duration
+
+	// PartialWriteProbability is the chance that a Put call writes a truncated value (simulating
+	// a crash mid-write) rather than the full value given.
+	PartialWriteProbability float64
+
+	// IterBreakProbability is the chance that a Query's result iterator stops early, as if the
+	// underlying store had failed partway through a scan.
+	IterBreakProbability float64
+}
+
+// ErrInjectedFault is returned in place of the wrapped store's own error when a fault is
+// injected.
+var ErrInjectedFault = errors.New("faultds: injected fault")
+
+// Datastore wraps a [datastore.RootStore], injecting faults seeded by a deterministic RNG so that
+// a given seed always produces the same failure schedule against the same sequence of calls.
+type Datastore struct {
+	inner datastore.RootStore
+
+	mu  sync.Mutex
+	rng *rand.Rand
+	cfg Config
+}
+
+var _ ds.Datastore = (*Datastore)(nil)
+var _ ds.TxnDatastore = (*Datastore)(nil)
+
+// Wrap returns a new [Datastore] decorating inner, with its fault schedule seeded by seed.
+func Wrap(inner datastore.RootStore, seed int64, cfg Config) *Datastore {
+	return &Datastore{
+		inner: inner,
+		rng:   rand.New(rand.NewSource(seed)),
+		cfg:   cfg,
+	}
+}
+
+// SetConfig replaces the fault configuration in use, letting a test adjust fault rates part-way
+// through a run (e.g. via a `FaultInjection` test action) without losing the deterministic RNG
+// sequence already established by the seed.
+func (d *Datastore) SetConfig(cfg Config) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cfg = cfg
+}
+
+// maybeFault consults the seeded RNG and returns a non-nil error, or sleeps, according to the
+// current [Config]. It must be called with d.mu held.
+func (d *Datastore) maybeFault(ctx context.Context) error {
+	if d.cfg.LatencyProbability > 0 && d.rng.Float64() < d.cfg.LatencyProbability {
+		delay := time.Duration(d.rng.Int63n(int64(d.cfg.MaxLatency) + 1))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if d.cfg.ErrorProbability > 0 && d.rng.Float64() < d.cfg.ErrorProbability {
+		return ErrInjectedFault
+	}
+
+	return nil
+}
+
+func (d *Datastore) fault(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.maybeFault(ctx)
+}
+
+func (d *Datastore) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	if err := d.fault(ctx); err != nil {
+		return nil, err
+	}
+	return d.inner.Get(ctx, key)
+}
+
+func (d *Datastore) Has(ctx context.Context, key ds.Key) (bool, error) {
+	if err := d.fault(ctx); err != nil {
+		return false, err
+	}
+	return d.inner.Has(ctx, key)
+}
+
+func (d *Datastore) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	if err := d.fault(ctx); err != nil {
+		return 0, err
+	}
+	return d.inner.GetSize(ctx, key)
+}
+
+func (d *Datastore) Put(ctx context.Context, key ds.Key, value []byte) error {
+	if err := d.fault(ctx); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	partial := d.cfg.PartialWriteProbability > 0 && d.rng.Float64() < d.cfg.PartialWriteProbability
+	truncatedLen := 0
+	if partial {
+		truncatedLen = d.rng.Intn(len(value) + 1)
+	}
+	d.mu.Unlock()
+
+	if partial {
+		value = value[:truncatedLen]
+	}
+	return d.inner.Put(ctx, key, value)
+}
+
+func (d *Datastore) Delete(ctx context.Context, key ds.Key) error {
+	if err := d.fault(ctx); err != nil {
+		return err
+	}
+	return d.inner.Delete(ctx, key)
+}
+
+func (d *Datastore) Sync(ctx context.Context, prefix ds.Key) error {
+	if err := d.fault(ctx); err != nil {
+		return err
+	}
+	return d.inner.Sync(ctx, prefix)
+}
+
+func (d *Datastore) Close() error {
+	return d.inner.Close()
+}
+
+func (d *Datastore) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	if err := d.fault(ctx); err != nil {
+		return nil, err
+	}
+
+	results, err := d.inner.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	breakEarly := d.cfg.IterBreakProbability > 0 && d.rng.Float64() < d.cfg.IterBreakProbability
+	d.mu.Unlock()
+
+	if !breakEarly {
+		return results, nil
+	}
+	return truncatedResults(results), nil
+}
+
+func (d *Datastore) NewTransaction(ctx context.Context, readOnly bool) (ds.Txn, error) {
+	if err := d.fault(ctx); err != nil {
+		return nil, err
+	}
+
+	inner, err := d.inner.NewTransaction(ctx, readOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction{inner: inner, ds: d}, nil
+}
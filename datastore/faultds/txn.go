@@ -0,0 +1,116 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package faultds
+
+import (
+	"context"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// transaction wraps a transaction opened against the inner store, subjecting each of its calls to
+// the same latency and transient-error schedule as its parent [Datastore]. Partial writes and
+// iterator early termination are not applied here: Put always writes the full value and Query
+// returns the inner results untruncated.
+type transaction struct {
+	inner ds.Txn
+	ds    *Datastore
+}
+
+var _ ds.Txn = (*transaction)(nil)
+
+func (t *transaction) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	if err := t.ds.fault(ctx); err != nil {
+		return nil, err
+	}
+	return t.inner.Get(ctx, key)
+}
+
+func (t *transaction) Has(ctx context.Context, key ds.Key) (bool, error) {
+	if err := t.ds.fault(ctx); err != nil {
+		return false, err
+	}
+	return t.inner.Has(ctx, key)
+}
+
+func (t *transaction) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	if err := t.ds.fault(ctx); err != nil {
+		return 0, err
+	}
+	return t.inner.GetSize(ctx, key)
+}
+
+func (t *transaction) Put(ctx context.Context, key ds.Key, value []byte) error {
+	if err := t.ds.fault(ctx); err != nil {
+		return err
+	}
+	return t.inner.Put(ctx, key, value)
+}
+
+func (t *transaction) Delete(ctx context.Context, key ds.Key) error {
+	if err := t.ds.fault(ctx); err != nil {
+		return err
+	}
+	return t.inner.Delete(ctx, key)
+}
+
+func (t *transaction) Sync(ctx context.Context, prefix ds.Key) error {
+	if err := t.ds.fault(ctx); err != nil {
+		return err
+	}
+	return t.inner.Sync(ctx, prefix)
+}
+
+func (t *transaction) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	if err := t.ds.fault(ctx); err != nil {
+		return nil, err
+	}
+	return t.inner.Query(ctx, q)
+}
+
+// Commit is also subject to fault injection, per the `Commit` fault surface listed on
+// [Config.ErrorProbability] - a committed-looking transaction that actually failed is one of the most useful
+// failure modes to exercise.
+func (t *transaction) Commit(ctx context.Context) error {
+	if err := t.ds.fault(ctx); err != nil {
+		return err
+	}
+	return t.inner.Commit(ctx)
+}
+
+func (t *transaction) Discard(ctx context.Context) {
+	t.inner.Discard(ctx)
+}
+
+// truncatedResults wraps results so that iteration silently stops (as if the store had failed)
+// partway through, without returning an error to the consumer - mirroring how a real mid-scan
+// failure often surfaces as an incomplete result set rather than an explicit error.
+func truncatedResults(results dsq.Results) dsq.Results {
+	const truncateAfter = 1
+
+	out := make(chan dsq.Result)
+	go func() {
+		defer close(out)
+		defer results.Close()
+
+		count := 0
+		for result := range results.Next() {
+			if count >= truncateAfter {
+				return
+			}
+			out <- result
+			count++
+		}
+	}()
+
+	return dsq.ResultsWithChan(dsq.Query{}, out)
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pebble provides a [github.com/cockroachdb/pebble]-backed [datastore.RootStore]
+// implementation, registered under the name "pebble" so it can be selected alongside the other
+// backends in the integration test harness via DEFRA_BACKENDS.
+package pebble
+
+import (
+	"context"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/datastore"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+func init() {
+	datastore.RegisterBackend("pebble", func(ctx context.Context, path string) (datastore.RootStore, error) {
+		return NewDatastore(path, nil)
+	})
+}
+
+// Options configures a [Datastore]. A nil *Options is equivalent to pebble's own defaults.
+type Options struct {
+	*pebble.Options
+}
+
+// Datastore is a [datastore.RootStore] backed by a Pebble LSM-tree key-value store. Passing an
+// empty path opens an in-memory store.
+type Datastore struct {
+	db *pebble.DB
+}
+
+var _ ds.Datastore = (*Datastore)(nil)
+var _ ds.TxnDatastore = (*Datastore)(nil)
+
+// NewDatastore opens (or creates) a Pebble store rooted at path. An empty path opens an
+// in-memory store, useful for tests.
+func NewDatastore(path string, opts *Options) (*Datastore, error) {
+	var pebbleOpts *pebble.Options
+	if opts != nil {
+		pebbleOpts = opts.Options
+	}
+
+	if path == "" {
+		pebbleOpts = pebbleOpts.EnsureDefaults()
+		pebbleOpts.FS = vfs.NewMem()
+		path = "in-memory"
+	}
+
+	db, err := pebble.Open(path, pebbleOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Datastore{db: db}, nil
+}
+
+func (d *Datastore) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	value, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, ds.ErrNotFound
+		}
+		return nil, err
+	}
+	defer closer.Close()
+
+	out := make([]byte, len(value))
+	copy(out, value)
+	return out, nil
+}
+
+func (d *Datastore) Has(ctx context.Context, key ds.Key) (bool, error) {
+	_, closer, err := d.db.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer closer.Close()
+	return true, nil
+}
+
+func (d *Datastore) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	value, err := d.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return len(value), nil
+}
+
+func (d *Datastore) Put(ctx context.Context, key ds.Key, value []byte) error {
+	return d.db.Set(key.Bytes(), value, pebble.Sync)
+}
+
+func (d *Datastore) Delete(ctx context.Context, key ds.Key) error {
+	return d.db.Delete(key.Bytes(), pebble.Sync)
+}
+
+func (d *Datastore) Sync(ctx context.Context, prefix ds.Key) error {
+	return d.db.Flush()
+}
+
+func (d *Datastore) Close() error {
+	return d.db.Close()
+}
+
+func (d *Datastore) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	return queryPebble(d.db, q)
+}
+
+// NewTransaction returns a new transaction, wrapping a Pebble indexed batch (for both reads and
+// writes) and a Pebble snapshot that is held open but not read from, satisfying
+// [ds.TxnDatastore].
+func (d *Datastore) NewTransaction(ctx context.Context, readOnly bool) (ds.Txn, error) {
+	return &transaction{
+		db:       d.db,
+		snapshot: d.db.NewSnapshot(),
+		batch:    d.db.NewIndexedBatch(),
+		readOnly: readOnly,
+	}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pebble
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/pebble"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// pebbleReader is satisfied by both *pebble.DB and *pebble.Batch, letting [queryPebble] serve a
+// query against either the root store or an in-flight transaction's batch.
+type pebbleReader interface {
+	NewIter(o *pebble.IterOptions) (*pebble.Iterator, error)
+}
+
+// queryPebble runs a go-datastore query against reader by linearly scanning all keys under the
+// query's prefix and applying go-datastore's generic filter/order/limit/offset helpers - Pebble
+// itself only offers range iteration, not go-datastore's richer query semantics.
+func queryPebble(reader pebbleReader, q dsq.Query) (dsq.Results, error) {
+	iter, err := reader.NewIter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	prefix := ds.NewKey(q.Prefix).String()
+	if prefix != "/" {
+		prefix += "/"
+	}
+
+	var entries []dsq.Entry
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := string(iter.Key())
+		if !strings.HasPrefix(key, prefix) && prefix != "/" {
+			continue
+		}
+
+		entry := dsq.Entry{Key: key}
+		if !q.KeysOnly {
+			value := iter.Value()
+			entry.Value = make([]byte, len(value))
+			copy(entry.Value, value)
+		}
+		entries = append(entries, entry)
+	}
+
+	results := dsq.ResultsWithEntries(q, entries)
+	return dsq.NaiveQueryApply(q, results), nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pebble
+
+import (
+	"context"
+
+	"github.com/cockroachdb/pebble"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// transaction is a [ds.Txn] backed by a Pebble indexed batch (so reads observe the transaction's
+// own uncommitted writes).
+//
+// snapshot is held open for the lifetime of the transaction but is not currently read from: Get,
+// Has, and Query all read through batch, which is indexed directly over the live *pebble.DB, so
+// this transaction does NOT isolate its reads from writes committed by other, concurrent
+// transactions while it is open - a repeated read may observe a different value each time. Giving
+// this transaction the isolation its name implies would mean reading through snapshot (falling
+// through to batch only for keys the batch itself has written), which is not yet done here.
+type transaction struct {
+	db       *pebble.DB
+	snapshot *pebble.Snapshot
+	batch    *pebble.Batch
+	readOnly bool
+}
+
+var _ ds.Txn = (*transaction)(nil)
+
+func (t *transaction) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	value, closer, err := t.batch.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, ds.ErrNotFound
+		}
+		return nil, err
+	}
+	defer closer.Close()
+
+	out := make([]byte, len(value))
+	copy(out, value)
+	return out, nil
+}
+
+func (t *transaction) Has(ctx context.Context, key ds.Key) (bool, error) {
+	_, closer, err := t.batch.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer closer.Close()
+	return true, nil
+}
+
+func (t *transaction) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	value, err := t.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	return len(value), nil
+}
+
+func (t *transaction) Put(ctx context.Context, key ds.Key, value []byte) error {
+	if t.readOnly {
+		return errors.New("cannot write to a read-only transaction")
+	}
+	return t.batch.Set(key.Bytes(), value, nil)
+}
+
+func (t *transaction) Delete(ctx context.Context, key ds.Key) error {
+	if t.readOnly {
+		return errors.New("cannot write to a read-only transaction")
+	}
+	return t.batch.Delete(key.Bytes(), nil)
+}
+
+func (t *transaction) Sync(ctx context.Context, prefix ds.Key) error {
+	return nil
+}
+
+func (t *transaction) Query(ctx context.Context, q dsq.Query) (dsq.Results, error) {
+	return queryPebble(t.batch, q)
+}
+
+func (t *transaction) Commit(ctx context.Context) error {
+	defer t.snapshot.Close()
+	defer t.batch.Close()
+	if t.readOnly {
+		return nil
+	}
+	return t.db.Apply(t.batch, pebble.Sync)
+}
+
+func (t *transaction) Discard(ctx context.Context) {
+	_ = t.batch.Close()
+	_ = t.snapshot.Close()
+}
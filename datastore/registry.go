@@ -0,0 +1,53 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package datastore
+
+import (
+	"context"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// RootStore is the datastore interface a pluggable backend must implement to be usable as
+// DefraDB's root datastore - an alias over [ds.TxnDatastore] so that registered backends are
+// described in terms of this package rather than go-datastore directly.
+type RootStore = ds.TxnDatastore
+
+// BackendFactory constructs a new [RootStore] for a registered backend, e.g. opening a Badger or
+// Pebble store rooted at path. path may be ignored by in-memory backends.
+type BackendFactory func(ctx context.Context, path string) (RootStore, error)
+
+// backendRegistry holds the backends registered via [RegisterBackend], keyed by name.
+var backendRegistry = map[string]BackendFactory{}
+
+// RegisterBackend registers a named datastore backend, making it available to
+// [GetRegisteredBackend] under that name. Typically called from an `init` function in the
+// backend's own package, or by a consumer (such as the integration test harness) that wants to
+// make an existing [RootStore] constructor addressable by name.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendRegistry[name] = factory
+}
+
+// GetRegisteredBackend looks up a previously registered backend by name.
+func GetRegisteredBackend(name string) (BackendFactory, bool) {
+	factory, ok := backendRegistry[name]
+	return factory, ok
+}
+
+// RegisteredBackendNames returns the names of all currently registered backends, in registration
+// order is not guaranteed.
+func RegisteredBackendNames() []string {
+	names := make([]string, 0, len(backendRegistry))
+	for name := range backendRegistry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -12,6 +12,7 @@ package datastore
 
 import (
 	"context"
+	"sync"
 
 	ds "github.com/ipfs/go-datastore"
 
@@ -34,14 +35,40 @@ type Txn interface {
 
 	OnSuccess(fn func())
 	OnError(fn func())
+
+	// IsBatch reports whether this Txn accumulates writes into a [ds.Batch] rather than an MVCC
+	// transaction - see [NewBatchFrom]. Always false for a Txn returned by [NewTxnFrom].
+	IsBatch() bool
+
+	// Rootstore returns the shared reader/writer over the rootstore's raw, unnamespaced keyspace
+	// that this transaction's Datastore/Headstore/DAGstore accessors (see [MultiStore]) are
+	// themselves built from. Reads and writes issued through it participate in the same
+	// transaction as those accessors, so tools that need unnamespaced access - backup/restore,
+	// DB.PrintDump, ACP policy storage, external index builders - can share one transaction with
+	// core CRDT writes rather than opening a second one.
+	Rootstore() DSReaderWriter
+
+	// ReadSet returns the keys read (via Get, Has, or GetSize) since this transaction began.
+	//
+	// Only populated along the common, non-iterable backend path (see [recordingDatastore]);
+	// it is the integration test suite's transaction-conflict oracle (DEFRA_TXN_ORACLE=1) that
+	// consumes this, so an iterable backend simply won't be covered by that oracle yet.
+	ReadSet() []ds.Key
+
+	// WriteSet returns the keys written (via Put or Delete) since this transaction began. See
+	// ReadSet for the same non-iterable-backend caveat.
+	WriteSet() []ds.Key
 }
 
 type txn struct {
-	t ds.Txn
+	t    ds.Txn
+	root DSReaderWriter
 	MultiStore
 
 	successFns []func()
 	errorFns   []func()
+
+	recorder *txnRecorder
 }
 
 var _ Txn = (*txn)(nil)
@@ -54,12 +81,15 @@ func NewTxnFrom(ctx context.Context, rootstore ds.TxnDatastore, readonly bool) (
 		if err != nil {
 			return nil, err
 		}
-		multistore := MultiStoreFrom(rootTxn)
+		root := AsDSReaderWriter(rootTxn)
+		multistore := MultiStoreFrom(root)
 		return &txn{
 			rootTxn,
+			root,
 			multistore,
 			[]func(){},
 			[]func(){},
+			newTxnRecorder(),
 		}, nil
 	}
 
@@ -68,13 +98,16 @@ func NewTxnFrom(ctx context.Context, rootstore ds.TxnDatastore, readonly bool) (
 		return nil, err
 	}
 
-	root := AsDSReaderWriter(ShimTxnStore{rootTxn})
+	recorder := newTxnRecorder()
+	root := AsDSReaderWriter(newRecordingDatastore(ShimTxnStore{rootTxn}, recorder))
 	multistore := MultiStoreFrom(root)
 	return &txn{
 		rootTxn,
+		root,
 		multistore,
 		[]func(){},
 		[]func(){},
+		recorder,
 	}, nil
 }
 
@@ -93,6 +126,17 @@ func (t *txn) Discard(ctx context.Context) {
 	t.t.Discard(ctx)
 }
 
+// IsBatch always reports false: t is an MVCC transaction, not a batch - see [NewBatchFrom].
+func (t *txn) IsBatch() bool {
+	return false
+}
+
+// Rootstore returns the reader/writer that this transaction's [MultiStore] accessors were built
+// from - see [Txn.Rootstore].
+func (t *txn) Rootstore() DSReaderWriter {
+	return t.root
+}
+
 // OnSuccess registers a function to be called when the transaction is committed.
 func (txn *txn) OnSuccess(fn func()) {
 	if fn == nil {
@@ -121,6 +165,16 @@ func (txn *txn) runSuccessFns(ctx context.Context) {
 	}
 }
 
+// ReadSet returns the keys read since this transaction began.
+func (txn *txn) ReadSet() []ds.Key {
+	return txn.recorder.readSet()
+}
+
+// WriteSet returns the keys written (Put or Delete) since this transaction began.
+func (txn *txn) WriteSet() []ds.Key {
+	return txn.recorder.writeSet()
+}
+
 // Shim to make ds.Txn support ds.Datastore.
 type ShimTxnStore struct {
 	ds.Txn
@@ -131,6 +185,93 @@ func (ts ShimTxnStore) Sync(ctx context.Context, prefix ds.Key) error {
 	return ts.Txn.Commit(ctx)
 }
 
+// txnRecorder accumulates the read and write sets observed on a single transaction, keyed by the
+// exact datastore key touched. Recording always runs - the cost is a mutex-guarded map insert per
+// call - so the integration test suite's transaction-conflict oracle can inspect a transaction's
+// sets after the fact without the caller needing to opt in up front.
+type txnRecorder struct {
+	mu     sync.Mutex
+	reads  map[string]ds.Key
+	writes map[string]ds.Key
+}
+
+func newTxnRecorder() *txnRecorder {
+	return &txnRecorder{
+		reads:  map[string]ds.Key{},
+		writes: map[string]ds.Key{},
+	}
+}
+
+func (r *txnRecorder) recordRead(key ds.Key) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads[key.String()] = key
+}
+
+func (r *txnRecorder) recordWrite(key ds.Key) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes[key.String()] = key
+}
+
+func (r *txnRecorder) readSet() []ds.Key {
+	return keySlice(r, r.reads)
+}
+
+func (r *txnRecorder) writeSet() []ds.Key {
+	return keySlice(r, r.writes)
+}
+
+func keySlice(r *txnRecorder, set map[string]ds.Key) []ds.Key {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]ds.Key, 0, len(set))
+	for _, key := range set {
+		out = append(out, key)
+	}
+	return out
+}
+
+// recordingDatastore decorates a [ds.Datastore] with read/write-set capture for the transaction-
+// conflict oracle exposed via [Txn.ReadSet] and [Txn.WriteSet]. Only Get/Has/GetSize are treated
+// as reads and Put/Delete as writes - Query results are not tracked per-key, as the oracle reasons
+// about the point reads/writes a TransactionRequest2 action makes, not range scans.
+type recordingDatastore struct {
+	ds.Datastore
+	recorder *txnRecorder
+}
+
+var _ ds.Datastore = (*recordingDatastore)(nil)
+
+func newRecordingDatastore(inner ds.Datastore, recorder *txnRecorder) *recordingDatastore {
+	return &recordingDatastore{Datastore: inner, recorder: recorder}
+}
+
+func (r *recordingDatastore) Get(ctx context.Context, key ds.Key) ([]byte, error) {
+	r.recorder.recordRead(key)
+	return r.Datastore.Get(ctx, key)
+}
+
+func (r *recordingDatastore) Has(ctx context.Context, key ds.Key) (bool, error) {
+	r.recorder.recordRead(key)
+	return r.Datastore.Has(ctx, key)
+}
+
+func (r *recordingDatastore) GetSize(ctx context.Context, key ds.Key) (int, error) {
+	r.recorder.recordRead(key)
+	return r.Datastore.GetSize(ctx, key)
+}
+
+func (r *recordingDatastore) Put(ctx context.Context, key ds.Key, value []byte) error {
+	r.recorder.recordWrite(key)
+	return r.Datastore.Put(ctx, key, value)
+}
+
+func (r *recordingDatastore) Delete(ctx context.Context, key ds.Key) error {
+	r.recorder.recordWrite(key)
+	return r.Datastore.Delete(ctx, key)
+}
+
 // Close discards the transaction.
 func (ts ShimTxnStore) Close() error {
 	ts.Discard(context.TODO())
@@ -0,0 +1,55 @@
+// Copyright 2022 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"fmt"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errFieldKindNotFound           string = "no FieldKind matching given string was found"
+	errInvalidMergePatchElement    string = "merge patch array element must be an object"
+	errMergePatchElementMissingKey string = "merge patch array element is missing its key field"
+)
+
+// withFields appends the given key/value pairs to msg as "msg. K1: v1, K2: v2, ...", in the order
+// given - the shape every error constructor in this file uses to keep its message
+// machine-greppable without each one hand-rolling its own fmt.Sprintf.
+func withFields(msg string, kvs ...any) string {
+	for i := 0; i+1 < len(kvs); i += 2 {
+		sep := ". "
+		if i > 0 {
+			sep = ", "
+		}
+		msg = fmt.Sprintf("%s%s%s: %v", msg, sep, kvs[i], kvs[i+1])
+	}
+	return msg
+}
+
+// NewErrFieldKindNotFound returns a new error indicating that the given string could not be
+// matched to a known [client.FieldKind].
+func NewErrFieldKindNotFound(kind string) error {
+	return errors.New(withFields(errFieldKindNotFound, "Kind", kind))
+}
+
+// NewErrInvalidMergePatchElement returns a new error indicating that a keyed merge-patch array
+// contained an element that was not an object.
+func NewErrInvalidMergePatchElement(keyField string) error {
+	return errors.New(withFields(errInvalidMergePatchElement, "KeyField", keyField))
+}
+
+// NewErrMergePatchElementMissingKey returns a new error indicating that an element of a keyed
+// merge-patch array did not carry the declared key field.
+func NewErrMergePatchElementMissingKey(keyField string) error {
+	return errors.New(withFields(errMergePatchElementMissingKey, "KeyField", keyField))
+}
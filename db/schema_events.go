@@ -0,0 +1,120 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// schemaChangeSubscriberBufferSize is the number of events buffered per subscriber before the
+// broker starts dropping the oldest queued event to make room for the newest.
+const schemaChangeSubscriberBufferSize = 100
+
+// schemaChangeBroker fans out [client.SchemaChangeEvent]s to any number of subscribers.
+//
+// Backpressure is handled by dropping the oldest buffered event for a slow subscriber (counting
+// the loss) rather than blocking the publisher or growing the channel unbounded.
+type schemaChangeBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan client.SchemaChangeEvent]*uint64
+}
+
+func newSchemaChangeBroker() *schemaChangeBroker {
+	return &schemaChangeBroker{
+		subscribers: map[chan client.SchemaChangeEvent]*uint64{},
+	}
+}
+
+// subscribe registers a new subscriber and returns its receive channel.
+func (b *schemaChangeBroker) subscribe() <-chan client.SchemaChangeEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan client.SchemaChangeEvent, schemaChangeSubscriberBufferSize)
+	var drops uint64
+	b.subscribers[ch] = &drops
+	return ch
+}
+
+// publish delivers the given event to every subscriber, dropping the oldest buffered event (and
+// incrementing that subscriber's drop counter) for any subscriber whose buffer is full.
+func (b *schemaChangeBroker) publish(event client.SchemaChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch, drops := range b.subscribers {
+		select {
+		case ch <- event:
+			continue
+		default:
+		}
+
+		// Buffer is full - drop the oldest queued event to make room for this one.
+		select {
+		case <-ch:
+			atomic.AddUint64(drops, 1)
+		default:
+		}
+
+		select {
+		case ch <- event:
+		default:
+			atomic.AddUint64(drops, 1)
+		}
+	}
+}
+
+// defaultSchemaChangeBroker is the process-wide broker used by [db.subscribeSchemaChanges] and
+// [publishSchemaChange].
+var defaultSchemaChangeBroker = newSchemaChangeBroker()
+
+// subscribeSchemaChanges returns a channel that receives a [client.SchemaChangeEvent] whenever
+// addSchema, patchSchema, or mergeSchema successfully commits a change to the persisted
+// CollectionDescriptions.
+func (db *db) subscribeSchemaChanges(ctx context.Context) (<-chan client.SchemaChangeEvent, error) {
+	return defaultSchemaChangeBroker.subscribe(), nil
+}
+
+// publishSchemaChange builds a diff between the given before/after collection sets (keyed by
+// collection name) and, if it is non-empty, registers a hook on the given transaction so that the
+// diff is published to schema-change subscribers once (and only if) the transaction commits.
+func publishSchemaChange(
+	txn datastore.Txn,
+	before map[string]client.CollectionDescription,
+	after map[string]client.CollectionDescription,
+) {
+	diff := buildSchemaDiff(before, after)
+	if len(diff.Collections) == 0 {
+		return
+	}
+
+	txn.OnSuccess(func() {
+		defaultSchemaChangeBroker.publish(client.SchemaChangeEvent{
+			CommitTime: time.Now(),
+			Diff:       diff,
+		})
+	})
+}
+
+// descriptionsByName indexes the given collection descriptions by name.
+func descriptionsByName(descriptions []client.CollectionDescription) map[string]client.CollectionDescription {
+	byName := make(map[string]client.CollectionDescription, len(descriptions))
+	for _, desc := range descriptions {
+		byName[desc.Name] = desc
+	}
+	return byName
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/client"
+)
+
+func TestCombineSDLSources(t *testing.T) {
+	tests := []struct {
+		name     string
+		sources  []string
+		expected string
+	}{
+		{
+			name:     "single source with no delimiter is passed through unchanged",
+			sources:  []string{"type Author {\n\tname: String\n}"},
+			expected: "type Author {\n\tname: String\n}",
+		},
+		{
+			name: "a single source with delimiter-separated files is split into segments",
+			sources: []string{
+				"# --- file: author.graphql ---\ntype Author {\n\tname: String\n}\n" +
+					"# --- file: book.graphql ---\ntype Book {\n\tname: String\n}",
+			},
+			expected: "\n\ntype Author {\n\tname: String\n}\n\n\ntype Book {\n\tname: String\n}",
+		},
+		{
+			name: "multiple variadic sources are joined as a single unit",
+			sources: []string{
+				"type Author {\n\tname: String\n}",
+				"type Book {\n\tauthor: Author\n}",
+			},
+			expected: "type Author {\n\tname: String\n}\ntype Book {\n\tauthor: Author\n}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := combineSDLSources(test.sources)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMergeSchemaDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing map[string]any
+		patch    map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "new top-level key is added",
+			existing: map[string]any{"Name": "Author"},
+			patch:    map[string]any{"Description": "An author of books"},
+			expected: map[string]any{"Name": "Author", "Description": "An author of books"},
+		},
+		{
+			name:     "scalar key is overwritten",
+			existing: map[string]any{"Name": "Author"},
+			patch:    map[string]any{"Name": "Writer"},
+			expected: map[string]any{"Name": "Writer"},
+		},
+		{
+			name:     "nested object is merged rather than replaced",
+			existing: map[string]any{"Policy": map[string]any{"ID": "policy1", "Resource": "authors"}},
+			patch:    map[string]any{"Policy": map[string]any{"Resource": "writers"}},
+			expected: map[string]any{"Policy": map[string]any{"ID": "policy1", "Resource": "writers"}},
+		},
+		{
+			name:     "nested object patch against a missing key is taken as-is",
+			existing: map[string]any{},
+			patch:    map[string]any{"Policy": map[string]any{"ID": "policy1"}},
+			expected: map[string]any{"Policy": map[string]any{"ID": "policy1"}},
+		},
+		{
+			name:     "non-keyed array is replaced wholesale, not merged",
+			existing: map[string]any{"Sources": []any{"a", "b"}},
+			patch:    map[string]any{"Sources": []any{"c"}},
+			expected: map[string]any{"Sources": []any{"c"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := mergeSchemaDescription(test.existing, test.patch)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMergeSchemaDescription_SubstitutesFieldKindString(t *testing.T) {
+	existing := map[string]any{}
+	patch := map[string]any{
+		"Fields": []any{
+			map[string]any{"Name": "name", "Kind": "String"},
+		},
+	}
+
+	actual, err := mergeSchemaDescription(existing, patch)
+	require.NoError(t, err)
+
+	fields := actual["Fields"].([]any)
+	require.Len(t, fields, 1)
+	assert.Equal(t, client.FieldKindStringToEnumMapping["String"], fields[0].(map[string]any)["Kind"])
+}
+
+func TestMergeSchemaDescription_UnknownFieldKindStringReturnsError(t *testing.T) {
+	patch := map[string]any{
+		"Fields": []any{
+			map[string]any{"Name": "name", "Kind": "NotARealKind"},
+		},
+	}
+
+	_, err := mergeSchemaDescription(map[string]any{}, patch)
+	assert.ErrorIs(t, err, NewErrFieldKindNotFound("NotARealKind"))
+}
+
+func TestMergeKeyedSchemaArray(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []any
+		patch    []any
+		expected []any
+	}{
+		{
+			name:     "new keyed element is appended",
+			existing: []any{map[string]any{"Name": "name"}},
+			patch:    []any{map[string]any{"Name": "age"}},
+			expected: []any{
+				map[string]any{"Name": "name"},
+				map[string]any{"Name": "age"},
+			},
+		},
+		{
+			name:     "matching keyed element is merged, not replaced",
+			existing: []any{map[string]any{"Name": "name", "Description": "the name"}},
+			patch:    []any{map[string]any{"Name": "name", "Description": "a different description"}},
+			expected: []any{map[string]any{"Name": "name", "Description": "a different description"}},
+		},
+		{
+			name:     "$patch delete removes the matching element, preserving the rest",
+			existing: []any{map[string]any{"Name": "name"}, map[string]any{"Name": "age"}},
+			patch:    []any{map[string]any{"Name": "age", "$patch": "delete"}},
+			expected: []any{map[string]any{"Name": "name"}},
+		},
+		{
+			name:     "$patch replace wholly replaces the matching element rather than merging",
+			existing: []any{map[string]any{"Name": "name", "Description": "the name"}},
+			patch:    []any{map[string]any{"Name": "name", "$patch": "replace"}},
+			expected: []any{map[string]any{"Name": "name"}},
+		},
+		{
+			name:     "$patch replace on a key not yet present appends it",
+			existing: []any{},
+			patch:    []any{map[string]any{"Name": "name", "$patch": "replace"}},
+			expected: []any{map[string]any{"Name": "name"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := mergeKeyedSchemaArray(test.existing, test.patch, "Name")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestMergeKeyedSchemaArray_PatchElementNotObjectReturnsError(t *testing.T) {
+	_, err := mergeKeyedSchemaArray(nil, []any{"not an object"}, "Name")
+	assert.ErrorIs(t, err, NewErrInvalidMergePatchElement("Name"))
+}
+
+func TestMergeKeyedSchemaArray_PatchElementMissingKeyReturnsError(t *testing.T) {
+	_, err := mergeKeyedSchemaArray(nil, []any{map[string]any{"Description": "no Name field"}}, "Name")
+	assert.ErrorIs(t, err, NewErrMergePatchElementMissingKey("Name"))
+}
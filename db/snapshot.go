@@ -0,0 +1,122 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/sourcenetwork/defradb/acp"
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+// Snapshot writes every key/value pair held by the rootstore to w, as a sequence of entries each
+// consisting of a big-endian uint32 key length, the key bytes, a big-endian uint32 value length,
+// and the value bytes.
+//
+// The format is backend-independent by construction - it is produced by walking the rootstore's
+// own keyspace via [ds.Datastore.Query] rather than copying any backend-specific file layout, so
+// a snapshot taken against one registered backend may be [Restore]d into another.
+//
+// As this walks the entire rootstore rather than any one collection, the [acp] check it performs
+// is a single collection-scoped read gate under [acp.DefaultPolicyName], rather than one per key.
+func (db *db) Snapshot(ctx context.Context, w io.Writer) error {
+	if err := acp.CheckRead(ctx, acp.DefaultPolicyName, acp.Resource{Scope: acp.ScopeCollection}); err != nil {
+		return err
+	}
+
+	results, err := db.Root().Query(ctx, dsq.Query{})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if err := writeSnapshotEntry(w, []byte(result.Key), result.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore reads a snapshot produced by [Snapshot] from r and writes every entry it contains to
+// the rootstore, overwriting any existing keys.
+//
+// Gated by a single collection-scoped [acp] write check under [acp.DefaultPolicyName] - see
+// [Snapshot].
+func (db *db) Restore(ctx context.Context, r io.Reader) error {
+	if err := acp.CheckWrite(ctx, acp.DefaultPolicyName, acp.Resource{Scope: acp.ScopeCollection}); err != nil {
+		return err
+	}
+
+	for {
+		key, value, err := readSnapshotEntry(r)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := db.Root().Put(ctx, ds.NewKey(key), value); err != nil {
+			return err
+		}
+	}
+}
+
+func writeSnapshotEntry(w io.Writer, key []byte, value []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(key))); err != nil {
+		return err
+	}
+	if _, err := w.Write(key); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(len(value))); err != nil {
+		return err
+	}
+	if _, err := w.Write(value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSnapshotEntry(r io.Reader) (key string, value []byte, err error) {
+	var keyLen uint32
+	if err := binary.Read(r, binary.BigEndian, &keyLen); err != nil {
+		return "", nil, err
+	}
+
+	keyBytes := make([]byte, keyLen)
+	if _, err := io.ReadFull(r, keyBytes); err != nil {
+		return "", nil, err
+	}
+
+	var valueLen uint32
+	if err := binary.Read(r, binary.BigEndian, &valueLen); err != nil {
+		return "", nil, err
+	}
+
+	value = make([]byte, valueLen)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return "", nil, err
+	}
+
+	return string(keyBytes), value, nil
+}
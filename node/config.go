@@ -18,6 +18,7 @@ import (
 	cconnmgr "github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 )
 
@@ -31,6 +32,24 @@ type Options struct {
 	GRPCServerOptions []grpc.ServerOption
 	GRPCDialOptions   []grpc.DialOption
 	ConnManager       cconnmgr.ConnManager
+
+	// Registerer is the Prometheus registry that the node's metrics (peer count, replicator
+	// lag, subscription fan-out, ...) are registered against. If nil, a fresh
+	// [prometheus.NewRegistry] is used, so that tests creating multiple nodes don't
+	// accidentally share (and so pollute) one another's gauges.
+	Registerer prometheus.Registerer
+
+	// MaxQueryDepth is the greatest selection-set nesting depth an incoming request may have -
+	// see [planner.ComplexityBudget.MaxDepth]. Zero means no limit.
+	MaxQueryDepth int
+
+	// MaxQueryComplexity is the greatest estimated row-scan complexity an incoming request may
+	// have - see [planner.ComplexityBudget.MaxComplexity]. Zero means no limit.
+	MaxQueryComplexity uint64
+
+	// QueryTimeout bounds how long a single request may run for, enforced via a cancellable
+	// context derived inside `ExecRequest`. Zero means no timeout.
+	QueryTimeout time.Duration
 }
 
 type NodeOpt func(*Options) error
@@ -115,3 +134,42 @@ func ListenAddrs(addrs ...ma.Multiaddr) NodeOpt {
 		return nil
 	}
 }
+
+// WithMaxQueryDepth sets the greatest selection-set nesting depth a request may have before it is
+// rejected - see [planner.ComplexityBudget.MaxDepth]. A value of 0 disables the limit.
+func WithMaxQueryDepth(n int) NodeOpt {
+	return func(opt *Options) error {
+		opt.MaxQueryDepth = n
+		return nil
+	}
+}
+
+// WithMaxQueryComplexity sets the greatest estimated row-scan complexity a request may have
+// before it is rejected - see [planner.ComplexityBudget.MaxComplexity]. A value of 0 disables the
+// limit.
+func WithMaxQueryComplexity(n uint64) NodeOpt {
+	return func(opt *Options) error {
+		opt.MaxQueryComplexity = n
+		return nil
+	}
+}
+
+// WithQueryTimeout sets how long a single request may run for before its context is cancelled. A
+// value of 0 disables the timeout.
+func WithQueryTimeout(d time.Duration) NodeOpt {
+	return func(opt *Options) error {
+		opt.QueryTimeout = d
+		return nil
+	}
+}
+
+// WithRegisterer sets the Prometheus registry the node's metrics are registered against.
+//
+// This is primarily useful to tests, which can install a fresh registry per node (and per
+// restart) so that gauges don't leak stale values between test cases or node incarnations.
+func WithRegisterer(registerer prometheus.Registerer) NodeOpt {
+	return func(opt *Options) error {
+		opt.Registerer = registerer
+		return nil
+	}
+}
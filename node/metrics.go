@@ -0,0 +1,75 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package node
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// nodeRegistries associates a [*Node] with the [*prometheus.Registry] its metrics (peer count,
+// replicator lag, subscription fan-out, ...) are registered against - see [Options.Registerer].
+//
+// Kept alongside rather than as a struct field so that this file only needs to know about the
+// (externally defined) *Node type's identity, not its internal layout.
+var (
+	nodeRegistriesMu sync.Mutex
+	nodeRegistries   = map[*Node]*prometheus.Registry{}
+)
+
+// registryFor returns the registry in use for n, defaulting to (and remembering) a freshly
+// created one the first time it is asked for a given node.
+func registryFor(n *Node) *prometheus.Registry {
+	nodeRegistriesMu.Lock()
+	defer nodeRegistriesMu.Unlock()
+
+	if registry, isSet := nodeRegistries[n]; isSet {
+		return registry
+	}
+
+	registry := prometheus.NewRegistry()
+	nodeRegistries[n] = registry
+	return registry
+}
+
+// Registerer returns the Prometheus registry this node's metrics are registered against.
+func (n *Node) Registerer() prometheus.Registerer {
+	return registryFor(n)
+}
+
+// Gatherer returns the Prometheus registry this node's metrics are registered against, as a
+// [prometheus.Gatherer] - useful for scraping the node's current metric values in tests.
+func (n *Node) Gatherer() prometheus.Gatherer {
+	return registryFor(n)
+}
+
+// ResetMetrics installs a fresh, empty Prometheus registry for this node, discarding whatever
+// metrics (and their accumulated values) were previously registered.
+//
+// This is primarily useful to the integration test harness, which calls it from `restartNodes` so
+// that a node's first-ever gauges (peer count, replicator lag, subscription fan-out) after a
+// restart aren't mistaken for values carried over from a prior incarnation.
+func (n *Node) ResetMetrics() {
+	nodeRegistriesMu.Lock()
+	defer nodeRegistriesMu.Unlock()
+	nodeRegistries[n] = prometheus.NewRegistry()
+}
+
+// UnregisterMetrics forgets this node's registry entirely, rather than replacing it with an
+// empty one - call this once a [*Node] is being torn down for good (see `closeNodes` in the
+// integration test harness) so nodeRegistries does not keep every node ever created reachable,
+// and their associated metric state, for the lifetime of the process.
+func (n *Node) UnregisterMetrics() {
+	nodeRegistriesMu.Lock()
+	defer nodeRegistriesMu.Unlock()
+	delete(nodeRegistries, n)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"fmt"
+
+	"github.com/sourcenetwork/defradb/errors"
+)
+
+const (
+	errMaxQueryDepthExceeded      string = "request exceeds configured max query depth"
+	errMaxQueryComplexityExceeded string = "request exceeds configured max query complexity"
+)
+
+// RequestShape is a minimal, backend-agnostic description of a GQL request's selection-set
+// nesting and per-level filtering, sufficient for [EstimateComplexity] to reason about worst-case
+// row-scan cost without depending on the parsed request or plan graph - a one-to-many-to-one
+// request such as `{book: {publisher: {yearOpened: {_gt: ...}}}}` is represented as three nested
+// RequestShapes, one per traversed collection.
+type RequestShape struct {
+	// Collection is the name of the collection this level of the selection set scans, as passed
+	// to [CollectionStats.DocumentCount].
+	Collection string
+
+	// HasFilter is true if this level carries a `filter` argument, narrowing the rows scanned by
+	// [defaultFilteredSelectivity].
+	HasFilter bool
+
+	// Children are the nested relation selections (or `_sum`/`_count`/... aggregate sources)
+	// scanned per row of this level.
+	Children []RequestShape
+}
+
+// ComplexityBudget bounds how deep and how expensive a request is allowed to be - see
+// [CheckComplexity].
+//
+// This chunk has no concrete `Store.ExecRequest` implementation (nor a parsed-request type) for
+// this to be invoked from directly yet - `node.Options` (see `node.WithMaxQueryDepth` and
+// `node.WithMaxQueryComplexity`) carries the configured budget ready for that call site to build
+// a [RequestShape] from the parsed request and consume once the parsing/execution pipeline it
+// depends on exists in this chunk.
+type ComplexityBudget struct {
+	// MaxDepth is the greatest selection-set nesting depth allowed, or zero for no limit.
+	MaxDepth int
+
+	// MaxComplexity is the greatest estimated row-scan complexity allowed, or zero for no limit.
+	MaxComplexity uint64
+}
+
+// EstimateComplexity returns shape's selection-set depth and its estimated worst-case row-scan
+// complexity: each level's estimated row count ([estimateScanRows], as `@explain(type: predict)`
+// already uses for a single scan) multiplied across every ancestor level, since each parent row
+// re-scans its children.
+func EstimateComplexity(shape RequestShape) (depth int, complexity uint64) {
+	return estimateComplexity(shape, 1, 1)
+}
+
+func estimateComplexity(shape RequestShape, level int, parentRows uint64) (int, uint64) {
+	rows, _ := estimateScanRows(shape.Collection, shape.HasFilter)
+	levelComplexity := parentRows * rows
+
+	maxDepth := level
+	totalComplexity := levelComplexity
+	for _, child := range shape.Children {
+		childDepth, childComplexity := estimateComplexity(child, level+1, levelComplexity)
+		if childDepth > maxDepth {
+			maxDepth = childDepth
+		}
+		totalComplexity += childComplexity
+	}
+
+	return maxDepth, totalComplexity
+}
+
+// CheckComplexity estimates shape's cost via [EstimateComplexity] and returns an error if it
+// exceeds budget. A zero-valued field on budget disables that particular limit.
+func CheckComplexity(budget ComplexityBudget, shape RequestShape) error {
+	depth, complexity := EstimateComplexity(shape)
+
+	if budget.MaxDepth > 0 && depth > budget.MaxDepth {
+		return errors.New(fmt.Sprintf("%s. Depth: %v, Max: %v", errMaxQueryDepthExceeded, depth, budget.MaxDepth))
+	}
+
+	if budget.MaxComplexity > 0 && complexity > budget.MaxComplexity {
+		return errors.New(
+			fmt.Sprintf("%s. Complexity: %v, Max: %v", errMaxQueryComplexityExceeded, complexity, budget.MaxComplexity),
+		)
+	}
+
+	return nil
+}
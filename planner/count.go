@@ -0,0 +1,71 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+// countSource describes a single `_count(fieldName: {filter: ...})` argument passed to a
+// [countNode].
+type countSource struct {
+	filter    map[string]any
+	fieldName string
+}
+
+// countNode computes the number of related documents for one or more source fields.
+type countNode struct {
+	sources []countSource
+}
+
+var _ ExplainableNode = (*countNode)(nil)
+
+func init() {
+	RegisterExplainableNode("countNode", func() ExplainableNode { return &countNode{} })
+}
+
+// Simple returns the filter and field name of each source the count was requested over.
+func (n *countNode) Simple() map[string]any {
+	sources := make([]map[string]any, len(n.sources))
+	for i, s := range n.sources {
+		sources[i] = map[string]any{
+			"filter":    s.filter,
+			"fieldName": s.fieldName,
+		}
+	}
+
+	return map[string]any{
+		"sources": sources,
+	}
+}
+
+// Execute returns the same attributes as Simple - the counting work itself is reported by the
+// scans that feed this node.
+func (n *countNode) Execute() map[string]any {
+	return n.Simple()
+}
+
+// Debug returns no attributes, just the shape of the plan graph.
+func (n *countNode) Debug() map[string]any {
+	return map[string]any{}
+}
+
+// Predict returns the estimated cost of computing the count, summed across all of its sources -
+// the count itself always yields a single row, but the work behind it scales with the estimated
+// size of each source.
+func (n *countNode) Predict() map[string]any {
+	var estimatedCost float64
+	for _, source := range n.sources {
+		sourceRows, _ := estimateScanRows(source.fieldName, source.filter != nil)
+		estimatedCost += float64(sourceRows) * costPerRowScan
+	}
+
+	return map[string]any{
+		"estimatedRows": uint64(1),
+		"estimatedCost": estimatedCost,
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+const (
+	// defaultFilteredSelectivity is the fraction of a collection's documents assumed to survive
+	// a filtered scan, used in the absence of histogram statistics.
+	defaultFilteredSelectivity = 0.5
+	// costPerRowScan is the relative cost unit charged per estimated row scanned.
+	costPerRowScan = 1.0
+)
+
+// CollectionStats exposes the document-count metadata that `@explain(type: predict)` uses to
+// estimate plan cost and cardinality, without executing the plan.
+type CollectionStats interface {
+	// DocumentCount returns the estimated number of documents in the named collection.
+	DocumentCount(collectionName string) uint64
+}
+
+// stats is the [CollectionStats] provider used by predicting nodes. It defaults to nil, in which
+// case estimates are reported as zero.
+var stats CollectionStats
+
+// SetCollectionStats installs the [CollectionStats] provider used by `predict` explain requests.
+func SetCollectionStats(s CollectionStats) {
+	stats = s
+}
+
+// estimateScanRows returns the estimated row count and selectivity for a scan of the named
+// collection, optionally narrowed by a filter.
+func estimateScanRows(collectionName string, hasFilter bool) (estimatedRows uint64, selectivity float64) {
+	var total uint64
+	if stats != nil {
+		total = stats.DocumentCount(collectionName)
+	}
+
+	if !hasFilter {
+		return total, 1
+	}
+
+	return uint64(float64(total) * defaultFilteredSelectivity), defaultFilteredSelectivity
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package planner builds and executes the query plan graphs used to answer GQL requests, and
+// hosts the explain walker that reports on those graphs for `@explain` requests.
+package planner
+
+const (
+	// RequestTypeSimple corresponds to `@explain(type: simple)` - a dump of the plan graph.
+	RequestTypeSimple string = "simple"
+	// RequestTypeExecute corresponds to `@explain(type: execute)` - insights gathered by
+	// executing the plan graph.
+	RequestTypeExecute string = "execute"
+	// RequestTypeDebug corresponds to `@explain(type: debug)` - like simple, but without
+	// attributes.
+	RequestTypeDebug string = "debug"
+	// RequestTypePredict corresponds to `@explain(type: predict)` - estimated cost and
+	// cardinality, computed without executing the plan graph.
+	RequestTypePredict string = "predict"
+)
+
+// ExplainableNode is implemented by plan nodes that wish to contribute attributes to the explain
+// graph produced for `@explain` requests.
+//
+// Built-in nodes (scanNode, typeIndexJoin, countNode, ...) implement this directly. Third parties
+// embedding DefraDB may register their own plan nodes (e.g. a full-text scan or a vector-KNN
+// node) via [RegisterExplainableNode] and have them appear in `ExpectedAttributes` output under
+// `@explain` without patching the core planner.
+type ExplainableNode interface {
+	// Simple returns the attributes to report for a `simple` explain request - typically a dump
+	// of the node's static configuration (e.g. a scan's spans, or a join's collection names).
+	Simple() map[string]any
+
+	// Execute returns the attributes to report for an `execute` explain request - insights
+	// gathered by actually running the plan, such as row counts or time spent.
+	Execute() map[string]any
+
+	// Debug returns the attributes to report for a `debug` explain request - nodes are still
+	// walked, but no attributes are reported, giving a more compact view of the plan's shape.
+	Debug() map[string]any
+
+	// Predict returns estimated cost/cardinality attributes (e.g. `estimatedRows`,
+	// `estimatedCost`, and for scans `estimatedSelectivity`) for a `predict` explain request,
+	// computed from CRDT/document counts and index metadata without executing the plan.
+	Predict() map[string]any
+}
+
+// explainableNodeFactories holds the set of node-name -> factory registrations made via
+// [RegisterExplainableNode].
+var explainableNodeFactories = map[string]func() ExplainableNode{}
+
+// RegisterExplainableNode registers a factory for a named plan node, so that the explain walker
+// can construct and query it via [ExplainableNode] whenever it is encountered in a plan graph.
+//
+// This is the registration point for third-party plan nodes - it allows consumers embedding
+// DefraDB to add their own nodes (e.g. a full-text scan or a vector-KNN node) without needing to
+// patch this package.
+func RegisterExplainableNode(name string, factory func() ExplainableNode) {
+	explainableNodeFactories[name] = factory
+	planNodeNames[name] = struct{}{}
+}
+
+// RegisteredExplainableNodeNames returns the name of every plan node this package knows about -
+// the full built-in set (see planNodeNames in explain_graph.go; not every built-in node
+// implements [ExplainableNode], so this is not limited to [RegisterExplainableNode] calls) plus
+// any third-party name registered via [RegisterExplainableNode] - in no particular order.
+// Consumers that need to recognise or expose plan node names outside this package (e.g. the typed
+// `/api/v0/explain` GraphQL schema) should call this rather than hand-maintaining their own list,
+// so that a third-party node registered here appears there too.
+func RegisteredExplainableNodeNames() []string {
+	names := make([]string, 0, len(planNodeNames))
+	for name := range planNodeNames {
+		if name == "root" || name == "subType" {
+			// Graph-structure wrapper keys, not real plan node types - they have no GQL object
+			// type of their own.
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// explainAttributes returns the attributes that the given plan node reports for the given
+// explain request type, or an empty map if the node does not implement [ExplainableNode].
+func explainAttributes(requestType string, node any) map[string]any {
+	explainable, isExplainable := node.(ExplainableNode)
+	if !isExplainable {
+		return map[string]any{}
+	}
+
+	switch requestType {
+	case RequestTypeExecute:
+		return explainable.Execute()
+	case RequestTypeDebug:
+		return explainable.Debug()
+	case RequestTypePredict:
+		return explainable.Predict()
+	default:
+		return explainable.Simple()
+	}
+}
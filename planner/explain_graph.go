@@ -0,0 +1,113 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+// ExplainGraphNode is a typed view of a single node within an `@explain` result, built from the
+// `map[string]any` graph that the explain walker produces.
+//
+// Children are resolved lazily via [ExplainGraphNode.Children] rather than being materialized
+// eagerly for the whole graph, so that a GraphQL resolver serving a large plan only walks the
+// sub-tree a client actually asks for.
+type ExplainGraphNode struct {
+	name        string
+	attributes  map[string]any
+	rawChildren map[string]any
+}
+
+// Name returns the plan node's name, e.g. "scanNode" or "typeIndexJoin".
+func (n *ExplainGraphNode) Name() string {
+	return n.name
+}
+
+// Attributes returns the node's reported attributes, as produced for the explain request type
+// that generated this graph (simple, execute, debug, or predict).
+func (n *ExplainGraphNode) Attributes() map[string]any {
+	return n.attributes
+}
+
+// Children lazily builds and returns the node's child plan nodes, walking only this node's
+// immediate sub-tree rather than the graph as a whole.
+func (n *ExplainGraphNode) Children() []*ExplainGraphNode {
+	children := make([]*ExplainGraphNode, 0, len(n.rawChildren))
+	for name, value := range n.rawChildren {
+		if !isPlanNodeName(name) {
+			continue
+		}
+
+		if attrs, ok := value.(map[string]any); ok {
+			children = append(children, newExplainGraphNode(name, attrs))
+		}
+	}
+	return children
+}
+
+// BuildExplainGraph constructs the root [ExplainGraphNode] of the given raw explain result, as
+// returned by the explain walker for a `@explain` request.
+func BuildExplainGraph(raw map[string]any) *ExplainGraphNode {
+	return newExplainGraphNode("explain", raw)
+}
+
+func newExplainGraphNode(name string, raw map[string]any) *ExplainGraphNode {
+	attributes := make(map[string]any, len(raw))
+	children := make(map[string]any, len(raw))
+
+	for key, value := range raw {
+		if isPlanNodeName(key) {
+			children[key] = value
+		} else {
+			attributes[key] = value
+		}
+	}
+
+	return &ExplainGraphNode{
+		name:        name,
+		attributes:  attributes,
+		rawChildren: children,
+	}
+}
+
+// isPlanNodeName reports whether the given key is a structural plan node (or graph-root/join
+// wrapper) key, as opposed to an ordinary attribute.
+func isPlanNodeName(name string) bool {
+	_, isPlanNode := planNodeNames[name]
+	return isPlanNode
+}
+
+// planNodeNames holds the node keys that can appear in a plan graph: the "root"/"subType" wrapper
+// keys used by typeIndexJoin, the planner's own built-in node names, and - added directly by
+// [RegisterExplainableNode] - every name registered there, so a third-party node registered via
+// that hook is recognised here too without edits to this package.
+var planNodeNames = map[string]struct{}{
+	"root":    {},
+	"subType": {},
+
+	"averageNode":   {},
+	"countNode":     {},
+	"createNode":    {},
+	"dagScanNode":   {},
+	"deleteNode":    {},
+	"groupNode":     {},
+	"limitNode":     {},
+	"multiScanNode": {},
+	"orderNode":     {},
+	"parallelNode":  {},
+	"pipeNode":      {},
+	"scanNode":      {},
+	"selectNode":    {},
+	"selectTopNode": {},
+	"sumNode":       {},
+	"topLevelNode":  {},
+	"typeIndexJoin": {},
+	"typeJoinMany":  {},
+	"typeJoinOne":   {},
+	"updateNode":    {},
+	"valuesNode":    {},
+}
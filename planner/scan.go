@@ -0,0 +1,76 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+// span describes the key-range scanned by a [scanNode].
+type span struct {
+	Start string
+	End   string
+}
+
+// scanNode iterates over the documents of a single collection, optionally applying a filter.
+//
+// It is one of the first consumers of [ExplainableNode].
+type scanNode struct {
+	filter         map[string]any
+	collectionID   string
+	collectionName string
+	spans          []span
+	executionCount uint64
+}
+
+var _ ExplainableNode = (*scanNode)(nil)
+
+func init() {
+	RegisterExplainableNode("scanNode", func() ExplainableNode { return &scanNode{} })
+}
+
+// Simple returns the static configuration of the scan: its target collection and key spans.
+func (n *scanNode) Simple() map[string]any {
+	spans := make([]map[string]any, len(n.spans))
+	for i, s := range n.spans {
+		spans[i] = map[string]any{
+			"start": s.Start,
+			"end":   s.End,
+		}
+	}
+
+	return map[string]any{
+		"filter":         n.filter,
+		"collectionID":   n.collectionID,
+		"collectionName": n.collectionName,
+		"spans":          spans,
+	}
+}
+
+// Execute returns the static configuration plus the number of documents actually yielded.
+func (n *scanNode) Execute() map[string]any {
+	attrs := n.Simple()
+	attrs["iterations"] = n.executionCount
+	return attrs
+}
+
+// Debug returns no attributes, just the shape of the plan graph.
+func (n *scanNode) Debug() map[string]any {
+	return map[string]any{}
+}
+
+// Predict returns the estimated row count, cost, and selectivity of this scan, computed from
+// [CollectionStats] without executing the query.
+func (n *scanNode) Predict() map[string]any {
+	estimatedRows, selectivity := estimateScanRows(n.collectionName, n.filter != nil)
+
+	return map[string]any{
+		"estimatedRows":        estimatedRows,
+		"estimatedCost":        float64(estimatedRows) * costPerRowScan,
+		"estimatedSelectivity": selectivity,
+	}
+}
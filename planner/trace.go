@@ -0,0 +1,61 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer emits one span per plan node visited while executing an `@explain(type: execute)`
+// request, mirroring the parent/child structure of the returned explain graph.
+var tracer = otel.Tracer("github.com/sourcenetwork/defradb/planner")
+
+// StartNodeSpan starts a span for the given named plan node, tagged with the same attributes
+// that would be reported for an `execute` explain request, and returns a context that further
+// child-node spans should be started from so the resulting trace mirrors the plan graph's shape.
+//
+// The caller is responsible for calling End on the returned span once the node has finished
+// executing.
+func StartNodeSpan(ctx context.Context, nodeName string, attrs map[string]any) (context.Context, trace.Span) {
+	childCtx, span := tracer.Start(ctx, nodeName)
+	span.SetAttributes(attributesToKeyValues(attrs)...)
+	return childCtx, span
+}
+
+// attributesToKeyValues flattens an explain attributes map into OpenTelemetry key-value pairs,
+// falling back to fmt.Sprint for any value type that otel/attribute doesn't natively support.
+func attributesToKeyValues(attrs map[string]any) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for key, value := range attrs {
+		switch v := value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(key, v))
+		case uint64:
+			kvs = append(kvs, attribute.Int64(key, int64(v)))
+		case float64:
+			kvs = append(kvs, attribute.Float64(key, v))
+		default:
+			kvs = append(kvs, attribute.String(key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
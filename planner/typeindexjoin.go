@@ -0,0 +1,57 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package planner
+
+// typeIndexJoin joins a root collection to a related (sub) collection via its index, to satisfy
+// a one-to-many or one-to-one relation requested in a GQL selection set.
+type typeIndexJoin struct {
+	joinType    string
+	rootName    string
+	subTypeName string
+}
+
+var _ ExplainableNode = (*typeIndexJoin)(nil)
+
+func init() {
+	RegisterExplainableNode("typeIndexJoin", func() ExplainableNode { return &typeIndexJoin{} })
+}
+
+// Simple returns the join's type and the names of the two collections it joins.
+func (n *typeIndexJoin) Simple() map[string]any {
+	return map[string]any{
+		"joinType":    n.joinType,
+		"rootName":    n.rootName,
+		"subTypeName": n.subTypeName,
+	}
+}
+
+// Execute returns the same attributes as Simple - the join itself gathers no further metrics,
+// its children (the root and subType scans) report their own execution attributes.
+func (n *typeIndexJoin) Execute() map[string]any {
+	return n.Simple()
+}
+
+// Debug returns no attributes, just the shape of the plan graph.
+func (n *typeIndexJoin) Debug() map[string]any {
+	return map[string]any{}
+}
+
+// Predict returns the estimated row count of the join, computed as the root's estimated row
+// count without its own selectivity applied - the join itself gathers no further statistics, its
+// children (the root and subType scans) report their own predicted attributes.
+func (n *typeIndexJoin) Predict() map[string]any {
+	estimatedRows, _ := estimateScanRows(n.rootName, false)
+
+	return map[string]any{
+		"estimatedRows": estimatedRows,
+		"estimatedCost": float64(estimatedRows) * costPerRowScan,
+	}
+}
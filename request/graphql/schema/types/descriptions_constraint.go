@@ -0,0 +1,27 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+const (
+	constraintDirectiveDescription = "@constraint declares value-level validation rules for a " +
+		"field. These rules are parsed into client.FieldConstraints but are not yet enforced " +
+		"against document values on any create/update path - see client.FieldConstraints.Validate."
+
+	constraintMinArgDescription = "The minimum allowed numeric value (inclusive)."
+	constraintMaxArgDescription = "The maximum allowed numeric value (inclusive)."
+
+	constraintMinLengthArgDescription = "The minimum allowed length of a string value."
+	constraintMaxLengthArgDescription = "The maximum allowed length of a string value."
+
+	constraintPatternArgDescription = "A regular expression that a string value must match."
+	constraintOneOfArgDescription   = "The exhaustive list of values that are permitted."
+	constraintFormatArgDescription  = "A well-known format (e.g. email, url, uuid) that a string value must satisfy."
+)
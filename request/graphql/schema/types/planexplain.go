@@ -0,0 +1,133 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package types
+
+import (
+	"encoding/json"
+	"sync"
+
+	gql "github.com/graphql-go/graphql"
+
+	"github.com/sourcenetwork/defradb/planner"
+)
+
+// PlanNodeInterface is the common GraphQL interface implemented by every concrete plan node type,
+// letting a client walk an `@explain` result as a typed, introspectable graph instead of untyped
+// JSON.
+var PlanNodeInterface = gql.NewInterface(gql.InterfaceConfig{
+	Name:        "PlanNode",
+	Description: "PlanNode is a single node of a query plan graph, as reported by @explain.",
+	Fields: gql.Fields{
+		"name": &gql.Field{
+			Type:        gql.NewNonNull(gql.String),
+			Description: "The plan node's name, e.g. \"scanNode\" or \"typeIndexJoin\".",
+		},
+		"attributes": &gql.Field{
+			Type:        gql.String,
+			Description: "The node's reported attributes, JSON-encoded.",
+		},
+	},
+	ResolveType: func(p gql.ResolveTypeParams) *gql.Object {
+		node, isNode := p.Value.(*planner.ExplainGraphNode)
+		if !isNode {
+			return nil
+		}
+		return getPlanNodeObjectTypes()[node.Name()]
+	},
+})
+
+// planNodeObjectTypesOnce/planNodeObjectTypes back [getPlanNodeObjectTypes] - built lazily, on
+// first use, rather than at package var-initialization time, so that every plan node registered
+// via [planner.RegisterExplainableNode] - including a third-party node registered by a package
+// this one doesn't import, whose init() order relative to this package's is otherwise unspecified
+// - is reflected here. By the time a caller actually serves a request (e.g. constructing an
+// [ExplainHandler]), every package's init() has already run.
+var (
+	planNodeObjectTypesOnce sync.Once
+	planNodeObjectTypes     map[string]*gql.Object
+)
+
+// getPlanNodeObjectTypes returns one concrete gql.Object per plan node name known to the planner
+// package (see [planner.RegisteredExplainableNodeNames]), each implementing [PlanNodeInterface].
+// They all share the same field set, as the underlying data - an [planner.ExplainGraphNode] - is
+// shaped identically regardless of which node produced it; only their name and the attributes
+// they happen to carry differ.
+func getPlanNodeObjectTypes() map[string]*gql.Object {
+	planNodeObjectTypesOnce.Do(func() {
+		names := planner.RegisteredExplainableNodeNames()
+		types := make(map[string]*gql.Object, len(names))
+		for _, name := range names {
+			types[name] = gql.NewObject(gql.ObjectConfig{
+				Name:       name,
+				Interfaces: []*gql.Interface{PlanNodeInterface},
+				Fields: gql.Fields{
+					"name": &gql.Field{
+						Type:    gql.NewNonNull(gql.String),
+						Resolve: resolvePlanNodeName,
+					},
+					"attributes": &gql.Field{
+						Type:    gql.String,
+						Resolve: resolvePlanNodeAttributes,
+					},
+					"children": &gql.Field{
+						Type:    gql.NewList(PlanNodeInterface),
+						Resolve: resolvePlanNodeChildren,
+					},
+				},
+			})
+		}
+		planNodeObjectTypes = types
+	})
+	return planNodeObjectTypes
+}
+
+// PlanNodeObjectTypes returns the full set of concrete plan node object types, for registration
+// with the schema that serves the `/api/v0/explain` endpoint.
+func PlanNodeObjectTypes() []*gql.Object {
+	all := getPlanNodeObjectTypes()
+	types := make([]*gql.Object, 0, len(all))
+	for _, t := range all {
+		types = append(types, t)
+	}
+	return types
+}
+
+func resolvePlanNodeName(p gql.ResolveParams) (any, error) {
+	node, isNode := p.Source.(*planner.ExplainGraphNode)
+	if !isNode {
+		return nil, nil
+	}
+	return node.Name(), nil
+}
+
+func resolvePlanNodeAttributes(p gql.ResolveParams) (any, error) {
+	node, isNode := p.Source.(*planner.ExplainGraphNode)
+	if !isNode {
+		return nil, nil
+	}
+
+	attrsJSON, err := json.Marshal(node.Attributes())
+	if err != nil {
+		return nil, err
+	}
+	return string(attrsJSON), nil
+}
+
+// resolvePlanNodeChildren resolves a node's children lazily - [planner.ExplainGraphNode.Children]
+// only walks this node's immediate sub-tree, so a client that doesn't request nested `children`
+// fields never pays the cost of materializing the rest of the plan graph.
+func resolvePlanNodeChildren(p gql.ResolveParams) (any, error) {
+	node, isNode := p.Source.(*planner.ExplainGraphNode)
+	if !isNode {
+		return nil, nil
+	}
+	return node.Children(), nil
+}
@@ -15,14 +15,24 @@ import (
 )
 
 const (
-	ExplainLabel  string = "explain"
-	PrimaryLabel  string = "primary"
-	RelationLabel string = "relation"
+	ExplainLabel    string = "explain"
+	PrimaryLabel    string = "primary"
+	RelationLabel   string = "relation"
+	ConstraintLabel string = "constraint"
 
 	ExplainArgNameType string = "type"
 	ExplainArgSimple   string = "simple"
 	ExplainArgExecute  string = "execute"
 	ExplainArgDebug    string = "debug"
+	ExplainArgPredict  string = "predict"
+
+	ConstraintArgMin       string = "min"
+	ConstraintArgMax       string = "max"
+	ConstraintArgMinLength string = "minLength"
+	ConstraintArgMaxLength string = "maxLength"
+	ConstraintArgPattern   string = "pattern"
+	ConstraintArgOneOf     string = "oneOf"
+	ConstraintArgFormat    string = "format"
 )
 
 var (
@@ -59,6 +69,12 @@ var (
 				Value:       ExplainArgDebug,
 				Description: "Like simple explain, but more verbose nodes (no attributes).",
 			},
+
+			ExplainArgPredict: &gql.EnumValueConfig{
+				Value: ExplainArgPredict,
+				Description: "Estimates the cost and cardinality of the plan graph without " +
+					"executing it.",
+			},
 		},
 	})
 
@@ -106,6 +122,47 @@ var (
 			gql.DirectiveLocationFieldDefinition,
 		},
 	})
+
+	// ConstraintDirective @constraint is used to declare value-level validation rules for a
+	// field. The rules are parsed into [client.FieldConstraints]; they are not checked when a
+	// document is created or updated.
+	ConstraintDirective = gql.NewDirective(gql.DirectiveConfig{
+		Name:        ConstraintLabel,
+		Description: constraintDirectiveDescription,
+		Args: gql.FieldConfigArgument{
+			ConstraintArgMin: &gql.ArgumentConfig{
+				Description: constraintMinArgDescription,
+				Type:        gql.Float,
+			},
+			ConstraintArgMax: &gql.ArgumentConfig{
+				Description: constraintMaxArgDescription,
+				Type:        gql.Float,
+			},
+			ConstraintArgMinLength: &gql.ArgumentConfig{
+				Description: constraintMinLengthArgDescription,
+				Type:        gql.Int,
+			},
+			ConstraintArgMaxLength: &gql.ArgumentConfig{
+				Description: constraintMaxLengthArgDescription,
+				Type:        gql.Int,
+			},
+			ConstraintArgPattern: &gql.ArgumentConfig{
+				Description: constraintPatternArgDescription,
+				Type:        gql.String,
+			},
+			ConstraintArgOneOf: &gql.ArgumentConfig{
+				Description: constraintOneOfArgDescription,
+				Type:        gql.NewList(gql.String),
+			},
+			ConstraintArgFormat: &gql.ArgumentConfig{
+				Description: constraintFormatArgDescription,
+				Type:        gql.String,
+			},
+		},
+		Locations: []string{
+			gql.DirectiveLocationFieldDefinition,
+		},
+	})
 )
 
 func NewArgConfig(t gql.Type, description string) *gql.ArgumentConfig {
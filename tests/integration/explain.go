@@ -60,16 +60,42 @@ var (
 
 type PlanNodeTargetCase struct {
 	// Name of the plan node, whose attribute(s) we are targetting to be asserted.
+	//
+	// Ignored if [TargetPath] is set.
 	TargetNodeName string
 
 	// How many occurances of this target name to skip until target (0 means match first).
+	//
+	// Ignored if [TargetPath] is set.
 	OccurancesToSkip uint
 
+	// TargetPath, if set, locates the target node with a small JSONPath-like expression (e.g.
+	// `$.selectNode.typeIndexJoin.root.scanNode[?(@.collectionName=='book')]`) evaluated over the
+	// returned explain graph, instead of [TargetNodeName] + [OccurancesToSkip]. This lets a test
+	// target a node by its semantic position in the plan rather than by positional counting,
+	// which gets brittle once a plan contains several nodes of the same name.
+	//
+	// Supports child navigation (`.name`), wildcards (`.*`), array indexing (`[N]`), and simple
+	// predicate filters on attribute values (`[?(@.field=='value')]`).
+	TargetPath string
+
 	// If set to 'true' will include the nested node(s), with their attribute(s) as well.
 	IncludeChildNodes bool
 
 	// Expected value of the target node's attribute(s).
 	ExpectedAttributes any
+
+	// PredictedAttributes is the expected value of the target node's attributes for a `predict`
+	// explain request (`estimatedRows`, `estimatedCost`, `estimatedSelectivity`, ...).
+	//
+	// Unlike [ExpectedAttributes], numeric leaf values are compared with a tolerance window
+	// (see [PredictedTolerance]) rather than exact equality, since cardinality estimates are
+	// approximate.
+	PredictedAttributes any
+
+	// PredictedTolerance is the allowed absolute difference between an actual and expected
+	// numeric value within [PredictedAttributes]. Defaults to 0 (exact match) if unset.
+	PredictedTolerance float64
 }
 
 type ExplainRequest struct {
@@ -163,6 +189,9 @@ func assertExplainRequestResults(
 		require.Equal(t, lengthOfExpectedFullGraph, len(resultantData), description)
 		for index, actualResult := range resultantData {
 			if lengthOfExpectedFullGraph > index {
+				if diff := diffExplainGraphs(action.ExpectedFullGraph[index], actualResult); diff != "" {
+					t.Log(diff)
+				}
 				assert.Equal(
 					t,
 					action.ExpectedFullGraph[index],
@@ -180,6 +209,9 @@ func assertExplainRequestResults(
 		for index, actualResult := range resultantData {
 			// Trim away all attributes (non-plan nodes) from the returned full explain graph result.
 			actualResultWithoutAttributes := trimExplainAttributes(t, description, actualResult)
+			if diff := diffExplainGraphs(action.ExpectedPatterns[index], actualResultWithoutAttributes); diff != "" {
+				t.Log(diff)
+			}
 			assert.Equal(
 				t,
 				action.ExpectedPatterns[index],
@@ -205,21 +237,45 @@ func assertExplainTargetCase(
 	actualResults []map[string]any,
 ) {
 	for _, actualResult := range actualResults {
-		foundActualTarget, _, isFound := findTargetNode(
-			targetCase.TargetNodeName,
-			targetCase.OccurancesToSkip,
-			targetCase.IncludeChildNodes,
-			actualResult,
-		)
+		var foundActualTarget any
+		var isFound bool
+
+		if targetCase.TargetPath != "" {
+			foundActualTarget, isFound = findTargetNodeByPath(targetCase.TargetPath, actualResult)
+		} else {
+			foundActualTarget, _, isFound = findTargetNode(
+				targetCase.TargetNodeName,
+				targetCase.OccurancesToSkip,
+				targetCase.IncludeChildNodes,
+				actualResult,
+			)
+		}
 
 		if !isFound {
 			assert.Fail(
 				t,
-				"Expected target ["+targetCase.TargetNodeName+"], was not found in the explain graph.",
+				"Expected target ["+targetCase.TargetNodeName+targetCase.TargetPath+"], was not found in the explain graph.",
 				description,
 			)
 		}
 
+		if targetCase.PredictedAttributes != nil {
+			assertPredictedAttributes(
+				t,
+				description,
+				targetCase.PredictedAttributes,
+				foundActualTarget,
+				targetCase.PredictedTolerance,
+			)
+			continue
+		}
+
+		if expectedMap, actualMap, bothMaps := asExplainGraphMaps(targetCase.ExpectedAttributes, foundActualTarget); bothMaps {
+			if diff := diffExplainGraphs(expectedMap, actualMap); diff != "" {
+				t.Log(diff)
+			}
+		}
+
 		assert.Equal(
 			t,
 			targetCase.ExpectedAttributes,
@@ -229,6 +285,58 @@ func assertExplainTargetCase(
 	}
 }
 
+// asExplainGraphMaps type-asserts both values to map[string]any, succeeding only if both are.
+func asExplainGraphMaps(expected, actual any) (map[string]any, map[string]any, bool) {
+	expectedMap, expectedIsMap := expected.(map[string]any)
+	actualMap, actualIsMap := actual.(map[string]any)
+	return expectedMap, actualMap, expectedIsMap && actualIsMap
+}
+
+// assertPredictedAttributes asserts that the actual `predict` explain attributes match the
+// expected ones, comparing numeric leaf values within the given absolute tolerance rather than
+// requiring an exact match, since cardinality estimates are approximate.
+func assertPredictedAttributes(
+	t *testing.T,
+	description string,
+	expected any,
+	actual any,
+	tolerance float64,
+) {
+	switch expectedTyped := expected.(type) {
+	case map[string]any:
+		actualTyped, isMap := actual.(map[string]any)
+		if !assert.True(t, isMap, description) {
+			return
+		}
+
+		for key, expectedValue := range expectedTyped {
+			assertPredictedAttributes(t, description, expectedValue, actualTyped[key], tolerance)
+		}
+
+	case float64, int, uint64:
+		expectedFloat := toFloat64(expectedTyped)
+		actualFloat := toFloat64(actual)
+		assert.InDelta(t, expectedFloat, actualFloat, tolerance, description)
+
+	default:
+		assert.Equal(t, expected, actual, description)
+	}
+}
+
+// toFloat64 converts common numeric types encountered in explain attribute maps to float64.
+func toFloat64(value any) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
 // findTargetNode returns true if the targetName is found in the explain graph after skipping given number of
 // occurances, 0 means first occurance. The function also returns total occurances it encountered so far. The
 // returned count of 'matches' should always be <= occurance argument.
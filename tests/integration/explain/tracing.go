@@ -0,0 +1,53 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package explain
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// NewSpanRecorder installs an in-memory OpenTelemetry span recorder as the global tracer
+// provider for the duration of the test, so that the spans emitted by an `@explain(type:
+// execute)` request can be asserted on directly instead of relying solely on the returned
+// explain graph.
+//
+// The recorder is torn down automatically via t.Cleanup.
+func NewSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		require.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	return recorder
+}
+
+// AssertSpanNames asserts that the recorder captured exactly the given span names, in the order
+// they were ended - for a plan graph this mirrors a post-order walk of its nodes.
+func AssertSpanNames(t *testing.T, recorder *tracetest.SpanRecorder, expectedNames []string) {
+	spans := recorder.Ended()
+	actualNames := make([]string, len(spans))
+	for i, s := range spans {
+		actualNames[i] = s.Name()
+	}
+
+	require.Equal(t, expectedNames, actualNames)
+}
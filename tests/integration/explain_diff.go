@@ -0,0 +1,83 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiReset = "\x1b[0m"
+)
+
+// diffExplainGraphs walks expected vs. actual explain graphs (as returned by the explain walker)
+// and renders only the differing paths, e.g.:
+//
+//	root.selectNode.scanNode.filter.age.$gt: expected 18, got 21
+//
+// instead of testify's default whole-object diff, which is close to unreadable for deeply nested
+// plan graphs. It returns an empty string if the two graphs are equal.
+func diffExplainGraphs(expected, actual map[string]any) string {
+	var lines []string
+	diffExplainGraphNode("root", expected, actual, &lines)
+	return strings.Join(lines, "\n")
+}
+
+func diffExplainGraphNode(path string, expected, actual any, lines *[]string) {
+	if reflect.DeepEqual(expected, actual) {
+		return
+	}
+
+	expectedMap, expectedIsMap := expected.(map[string]any)
+	actualMap, actualIsMap := actual.(map[string]any)
+
+	if expectedIsMap && actualIsMap {
+		diffExplainGraphMaps(path, expectedMap, actualMap, lines)
+		return
+	}
+
+	switch {
+	case expected == nil:
+		*lines = append(*lines, fmt.Sprintf("%s: %s+%v%s (unexpected)", path, ansiGreen, actual, ansiReset))
+	case actual == nil:
+		*lines = append(*lines, fmt.Sprintf("%s: %s-%v%s (missing)", path, ansiRed, expected, ansiReset))
+	default:
+		*lines = append(*lines, fmt.Sprintf(
+			"%s: expected %s%v%s, got %s%v%s",
+			path, ansiRed, expected, ansiReset, ansiGreen, actual, ansiReset,
+		))
+	}
+}
+
+func diffExplainGraphMaps(path string, expected, actual map[string]any, lines *[]string) {
+	keys := make(map[string]struct{}, len(expected)+len(actual))
+	for key := range expected {
+		keys[key] = struct{}{}
+	}
+	for key := range actual {
+		keys[key] = struct{}{}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, key := range sortedKeys {
+		diffExplainGraphNode(path+"."+key, expected[key], actual[key], lines)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// segmentPattern splits a single path segment, e.g. `scanNode[?(@.collectionName=='book')]`, into
+// its node name and an optional bracket expression.
+var segmentPattern = regexp.MustCompile(`^([\w*]+)(?:\[(.*)])?$`)
+
+// predicatePattern matches a `?(@.field=='value')` or `?(@.field==123)` bracket expression.
+var predicatePattern = regexp.MustCompile(`^\?\(@\.(\w+)==(.*)\)$`)
+
+// findTargetNodeByPath evaluates a small JSONPath-like expression (e.g.
+// `$.selectNode.typeIndexJoin.root.scanNode[?(@.collectionName=='book')]`) against the given
+// explain graph, returning the node it addresses.
+//
+// Supported syntax:
+//   - `.name`       - navigate into the child plan node (or attribute) called `name`.
+//   - `.*`          - navigate into whichever child plan node is present (first match wins).
+//   - `[N]`         - index into a list-valued attribute or child.
+//   - `[?(@.f==v)]` - select the first item (of a list) or keep the current node (of a map) whose
+//     attribute `f` equals `v`.
+func findTargetNodeByPath(path string, graph any) (any, bool) {
+	segments := strings.Split(path, ".")
+	if len(segments) == 0 {
+		return nil, false
+	}
+
+	// The leading `$` segment denotes the graph root and carries no navigation of its own.
+	if segments[0] == "$" {
+		segments = segments[1:]
+	}
+
+	return evaluateTargetPath(graph, segments)
+}
+
+func evaluateTargetPath(node any, segments []string) (any, bool) {
+	if len(segments) == 0 {
+		return node, true
+	}
+
+	name, bracket, err := parseTargetPathSegment(segments[0])
+	if err != nil {
+		return nil, false
+	}
+	rest := segments[1:]
+
+	nodeMap, isMap := node.(map[string]any)
+	if !isMap {
+		return nil, false
+	}
+
+	if name == "*" {
+		for _, key := range sortedExplainGraphKeys(nodeMap) {
+			if !isPlanNode(key) {
+				continue
+			}
+			if result, found := evaluateTargetPathValue(nodeMap[key], bracket, rest); found {
+				return result, true
+			}
+		}
+		return nil, false
+	}
+
+	value, exists := nodeMap[name]
+	if !exists {
+		return nil, false
+	}
+	return evaluateTargetPathValue(value, bracket, rest)
+}
+
+func evaluateTargetPathValue(value any, bracket string, rest []string) (any, bool) {
+	if bracket == "" {
+		return evaluateTargetPath(value, rest)
+	}
+
+	if list, isList := value.([]map[string]any); isList {
+		items := make([]any, len(list))
+		for i, item := range list {
+			items[i] = item
+		}
+		return evaluateTargetPathList(items, bracket, rest)
+	}
+
+	field, expected, isPredicate := parseTargetPathPredicate(bracket)
+	if !isPredicate {
+		// A numeric index or `*` against a non-list value has nothing to index into.
+		return nil, false
+	}
+
+	nodeMap, isMap := value.(map[string]any)
+	if !isMap || !targetPathValueEquals(nodeMap[field], expected) {
+		return nil, false
+	}
+	return evaluateTargetPath(nodeMap, rest)
+}
+
+func evaluateTargetPathList(items []any, bracket string, rest []string) (any, bool) {
+	if bracket == "*" {
+		for _, item := range items {
+			if result, found := evaluateTargetPath(item, rest); found {
+				return result, true
+			}
+		}
+		return nil, false
+	}
+
+	if index, err := strconv.Atoi(bracket); err == nil {
+		if index < 0 || index >= len(items) {
+			return nil, false
+		}
+		return evaluateTargetPath(items[index], rest)
+	}
+
+	if field, expected, isPredicate := parseTargetPathPredicate(bracket); isPredicate {
+		for _, item := range items {
+			itemMap, isMap := item.(map[string]any)
+			if !isMap || !targetPathValueEquals(itemMap[field], expected) {
+				continue
+			}
+			if result, found := evaluateTargetPath(itemMap, rest); found {
+				return result, true
+			}
+		}
+		return nil, false
+	}
+
+	return nil, false
+}
+
+func parseTargetPathSegment(segment string) (name string, bracket string, err error) {
+	matches := segmentPattern.FindStringSubmatch(segment)
+	if matches == nil {
+		return "", "", fmt.Errorf("invalid explain target path segment: %q", segment)
+	}
+	return matches[1], matches[2], nil
+}
+
+// parseTargetPathPredicate parses a `?(@.field==value)` bracket expression, returning the
+// attribute name and the expected value (with surrounding quotes stripped, if any).
+func parseTargetPathPredicate(bracket string) (field string, expected string, ok bool) {
+	matches := predicatePattern.FindStringSubmatch(bracket)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], strings.Trim(matches[2], `'"`), true
+}
+
+func targetPathValueEquals(actual any, expected string) bool {
+	return fmt.Sprint(actual) == expected
+}
+
+func sortedExplainGraphKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcenetwork/immutable"
+
+	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
+	"github.com/sourcenetwork/defradb/errors"
+	"github.com/sourcenetwork/defradb/node"
+)
+
+// FailPointMode selects how a [FailPoint] decides whether it fires on a given matching call,
+// mirroring the modes MongoDB's configureFailPoint supports.
+type FailPointMode string
+
+const (
+	// FailPointModeAlwaysOn fires on every matching call, until cleared via [ClearFailPoints].
+	FailPointModeAlwaysOn FailPointMode = "alwaysOn"
+	// FailPointModeTimes fires on the next Times matching calls, then stops firing.
+	FailPointModeTimes FailPointMode = "times"
+	// FailPointModeSkip lets the first Skip matching calls through untouched, then behaves as
+	// FailPointModeAlwaysOn.
+	FailPointModeSkip FailPointMode = "skip"
+)
+
+// FailPointError selects the error a firing [FailPoint] substitutes for the operation's real
+// result.
+type FailPointError string
+
+const (
+	// FailPointErrTxnConflict injects [badgerds.ErrTxnConflict], the error withRetry retries on -
+	// use this to assert retry behaviour at the boundary (eventual success, or surfacing the error
+	// once MaxTxnRetries is exhausted).
+	FailPointErrTxnConflict FailPointError = "txnConflict"
+	// FailPointErrContextCanceled injects [context.Canceled], which withRetry does not retry on.
+	FailPointErrContextCanceled FailPointError = "contextCanceled"
+	// FailPointErrP2PDisconnect injects a synthetic "peer unreachable" error, standing in for a
+	// dropped P2P connection without actually tearing one down.
+	FailPointErrP2PDisconnect FailPointError = "p2pDisconnect"
+)
+
+// errP2PDisconnect is the error injected by [FailPointErrP2PDisconnect].
+var errP2PDisconnect = errors.New("failpoint: synthetic p2p disconnect")
+
+// toError resolves e to the concrete error it injects.
+func (e FailPointError) toError() error {
+	switch e {
+	case FailPointErrTxnConflict:
+		return badgerds.ErrTxnConflict
+	case FailPointErrContextCanceled:
+		return context.Canceled
+	case FailPointErrP2PDisconnect:
+		return errP2PDisconnect
+	default:
+		return errors.New(string(e))
+	}
+}
+
+// FailPoint installs a deterministic fault - analogous to MongoDB's configureFailPoint - that
+// causes the next matching Save/Delete/Request operation(s) on a node to fail with Error instead
+// of reaching the database, so a test can assert both "we retried and eventually succeeded" and
+// "we surfaced the error after exhausting retries" without relying on the probabilistic schedule
+// [FaultInjection] installs happening to land on the right call.
+//
+// Unlike [FaultInjection], which wraps the rootstore and so cannot distinguish one collection's
+// keys from another's, FailPoint is enforced by the harness itself at the point it is about to
+// call Save/DeleteWithKey/ExecRequest - so it can be scoped to CollectionID. A Request action has
+// no collection of its own; a FailPoint with CollectionID unset is the only kind that applies to
+// it, collection-scoped FailPoints only ever match CreateDoc/UpdateDoc/DeleteDoc.
+type FailPoint struct {
+	// NodeID is the node the FailPoint applies to. If not provided, the first node is used.
+	NodeID immutable.Option[int]
+
+	// CollectionID restricts the FailPoint to Save/Delete operations against this collection. If
+	// not provided the FailPoint matches every collection, and Request actions too.
+	CollectionID immutable.Option[int]
+
+	// Mode selects when a matching call fires - see [FailPointMode].
+	Mode FailPointMode
+
+	// Error selects which error a firing call injects - see [FailPointError].
+	Error FailPointError
+
+	// Times is the number of matching calls that fire, when Mode is [FailPointModeTimes].
+	Times int
+
+	// Skip is the number of matching calls let through before firing starts, when Mode is
+	// [FailPointModeSkip].
+	Skip int
+}
+
+// ClearFailPoints removes previously installed FailPoints.
+type ClearFailPoints struct {
+	// NodeID restricts the clear to a single node's FailPoints. If not provided, every node's are
+	// cleared.
+	NodeID immutable.Option[int]
+
+	// CollectionID, if provided, only clears FailPoints registered with that same CollectionID
+	// (collection-scoped FailPoints registered with no CollectionID are left in place, and vice
+	// versa). If not provided, all of the selected node(s)' FailPoints are cleared.
+	CollectionID immutable.Option[int]
+}
+
+// failPointEntry is a single installed FailPoint's live counter state.
+type failPointEntry struct {
+	collectionID immutable.Option[int]
+	mode         FailPointMode
+	err          FailPointError
+	remaining    int
+}
+
+// fire consults and, if necessary, advances e's counter, returning the error to inject and true if
+// this call should fail. Must be called with failPointsMu held.
+func (e *failPointEntry) fire() (error, bool) {
+	switch e.mode {
+	case FailPointModeAlwaysOn:
+		return e.err.toError(), true
+
+	case FailPointModeTimes:
+		if e.remaining <= 0 {
+			return nil, false
+		}
+		e.remaining--
+		return e.err.toError(), true
+
+	case FailPointModeSkip:
+		if e.remaining > 0 {
+			e.remaining--
+			return nil, false
+		}
+		return e.err.toError(), true
+
+	default:
+		return nil, false
+	}
+}
+
+// failPointsMu guards failPoints.
+var failPointsMu sync.Mutex
+
+// failPoints maps a node to the FailPoints currently installed against it.
+var failPoints = map[*node.Node][]*failPointEntry{}
+
+// installFailPoint implements the FailPoint action.
+func installFailPoint(nodes []*node.Node, action FailPoint) {
+	entry := &failPointEntry{
+		collectionID: action.CollectionID,
+		mode:         action.Mode,
+		err:          action.Error,
+	}
+	switch action.Mode {
+	case FailPointModeTimes:
+		entry.remaining = action.Times
+	case FailPointModeSkip:
+		entry.remaining = action.Skip
+	}
+
+	failPointsMu.Lock()
+	defer failPointsMu.Unlock()
+	for _, n := range getNodes(action.NodeID, nodes) {
+		failPoints[n] = append(failPoints[n], entry)
+	}
+}
+
+// clearFailPoints implements the ClearFailPoints action.
+func clearFailPoints(nodes []*node.Node, action ClearFailPoints) {
+	failPointsMu.Lock()
+	defer failPointsMu.Unlock()
+
+	for _, n := range getNodes(action.NodeID, nodes) {
+		if !action.CollectionID.HasValue() {
+			delete(failPoints, n)
+			continue
+		}
+
+		kept := failPoints[n][:0]
+		for _, entry := range failPoints[n] {
+			if entry.collectionID.HasValue() && entry.collectionID.Value() == action.CollectionID.Value() {
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		failPoints[n] = kept
+	}
+}
+
+// forgetFailPoints discards n's entry in failPoints entirely, rather than just clearing its
+// FailPoints - call this once n is being torn down for good (see `closeNodes`) so failPoints does
+// not keep every node ever created reachable for the lifetime of the process, the same leak
+// [node.Node.UnregisterMetrics] was added to fix for nodeRegistries.
+func forgetFailPoints(n *node.Node) {
+	failPointsMu.Lock()
+	defer failPointsMu.Unlock()
+	delete(failPoints, n)
+}
+
+// checkFailPoint returns the error a FailPoint installed against n wants injected in place of an
+// operation against collectionID (immutable.None for a Request, which has no collection of its
+// own), or nil if none of n's FailPoints match or none fired.
+func checkFailPoint(n *node.Node, collectionID immutable.Option[int]) error {
+	failPointsMu.Lock()
+	defer failPointsMu.Unlock()
+
+	for _, entry := range failPoints[n] {
+		if entry.collectionID.HasValue() {
+			if !collectionID.HasValue() || collectionID.Value() != entry.collectionID.Value() {
+				continue
+			}
+		}
+		if err, fires := entry.fire(); fires {
+			return err
+		}
+	}
+	return nil
+}
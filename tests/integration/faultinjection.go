@@ -0,0 +1,50 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/datastore/faultds"
+	"github.com/sourcenetwork/defradb/node"
+)
+
+// FaultInjection (re)configures the seeded fault-injection schedule for a node's rootstore - see
+// [faultds.Datastore]. It requires the test run to have been started with DEFRA_FAULT_SEED set,
+// as that is what wraps each node's rootstore with a [faultds.Datastore] in the first place.
+type FaultInjection struct {
+	// NodeID is the node whose fault schedule should be updated. If not provided the first node
+	// will be used.
+	NodeID immutable.Option[int]
+
+	// Config is the fault configuration to install, replacing whatever was previously active.
+	Config faultds.Config
+}
+
+func injectFaults(
+	t *testing.T,
+	nodes []*node.Node,
+	action FaultInjection,
+) {
+	for _, node := range getNodes(action.NodeID, nodes) {
+		injector, isWrapped := faultInjectors[node.DB]
+		require.True(
+			t,
+			isWrapped,
+			"FaultInjection requires the test run to be started with %s set",
+			faultSeedEnvName,
+		)
+		injector.SetConfig(action.Config)
+	}
+}
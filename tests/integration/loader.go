@@ -0,0 +1,331 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// specDocument is the top-level shape of a YAML or JSON test-case spec file loaded by RunFile. It
+// describes the same thing a hand-written Go TestCase literal does, but as data - in the spirit
+// of MongoDB's unified test-format runner - so contributors and downstream consumers can add
+// regression cases (or share them across language bindings) without recompiling.
+type specDocument struct {
+	// Description mirrors TestCase.Description.
+	Description string `yaml:"description" json:"description"`
+
+	// RunOn gates the whole file to environments matching at least one of the given
+	// requirements. An empty (or absent) list means "run everywhere".
+	RunOn []specGate `yaml:"runOn" json:"runOn"`
+
+	// InitialData seeds named collections with documents before Actions runs, keyed by
+	// collection name (as passed to RunFile). Each seeded document is assigned the symbolic
+	// docID it declares, for later actions to refer back to it by name.
+	InitialData map[string][]specDoc `yaml:"initialData" json:"initialData"`
+
+	// Actions is the ordered list of actions to run after InitialData has been seeded.
+	Actions []specAction `yaml:"actions" json:"actions"`
+}
+
+// specGate restricts a spec file to environments matching all of its non-empty fields -
+// analogous to MongoDB unified-spec runOnRequirements. An empty specGate always matches.
+type specGate struct {
+	// DatabaseType, if set, must equal one of GetDatabaseTypes()'s entries for this gate to be
+	// satisfied, e.g. "badger-in-memory".
+	DatabaseType string `yaml:"databaseType" json:"databaseType"`
+}
+
+// specDoc is a single seed document within InitialData.
+type specDoc struct {
+	// DocID is the symbolic name later actions use to refer back to this document, e.g. via
+	// specAction.DocID. Optional - a document that is never referenced again does not need one.
+	DocID string `yaml:"docID" json:"docID"`
+
+	// Doc is the document body, keyed by field name.
+	Doc map[string]any `yaml:"doc" json:"doc"`
+}
+
+// specAction is one entry in a spec file's `actions` array. Name selects which existing action
+// executor the loader dispatches to (e.g. "createDoc", "request"); the remaining fields are
+// interpreted according to Name - see buildAction. Fields that don't apply to a given Name are
+// simply left at their zero value in the file.
+type specAction struct {
+	// Name selects the action, e.g. createDoc, updateDoc, deleteDoc, schemaUpdate, patchSchema,
+	// request, transactionRequest, transactionCommit, subscriptionRequest.
+	Name string `yaml:"name" json:"name"`
+
+	// NodeID restricts the action to a single node, mirroring the NodeID field most Go action
+	// literals already have. Nil means "all nodes".
+	NodeID *int `yaml:"nodeID" json:"nodeID"`
+
+	// CollectionID is the collection name (as passed to RunFile), resolved to the numeric index
+	// the Go action literals use.
+	CollectionID string `yaml:"collectionID" json:"collectionID"`
+
+	// DocID is a symbolic document name, either freshly registered (createDoc) or resolved back
+	// to a prior registration (updateDoc, deleteDoc). A plain integer literal (e.g. "0") is also
+	// accepted in place of a registered name, so positional and symbolic references can mix.
+	DocID string `yaml:"docID" json:"docID"`
+
+	// Doc is the document body for createDoc/updateDoc.
+	Doc map[string]any `yaml:"doc" json:"doc"`
+
+	// Schema is the SDL for schemaUpdate.
+	Schema string `yaml:"schema" json:"schema"`
+
+	// Patch is the JSON Patch (or merge patch) document for patchSchema.
+	Patch string `yaml:"patch" json:"patch"`
+
+	// Request is the GraphQL request body for request, transactionRequest, and
+	// subscriptionRequest.
+	Request string `yaml:"request" json:"request"`
+
+	// TransactionID identifies the transaction for transactionRequest and transactionCommit.
+	TransactionID int `yaml:"transactionID" json:"transactionID"`
+
+	// Results is the expected GraphQL result set for request, transactionRequest, and
+	// subscriptionRequest.
+	Results []map[string]any `yaml:"expectedResults" json:"expectedResults"`
+
+	// ExpectedError is the expected error substring, if any.
+	ExpectedError string `yaml:"expectedError" json:"expectedError"`
+}
+
+// RunFile loads the TestCase described by the YAML or JSON spec file at path and runs it via
+// ExecuteTestCase. JSON parses fine here too, as it is a valid subset of YAML.
+func RunFile(t *testing.T, collectionNames []string, path string) {
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err, "reading spec file %q", path)
+
+	var doc specDocument
+	err = yaml.Unmarshal(contents, &doc)
+	require.NoError(t, err, "parsing spec file %q", path)
+
+	if !gatesSatisfied(doc.RunOn) {
+		t.Skipf("spec file %q: none of its runOn requirements are satisfied in this environment", path)
+		return
+	}
+
+	ExecuteTestCase(t, collectionNames, buildTestCase(t, collectionNames, doc))
+}
+
+// gatesSatisfied reports whether at least one gate in gates is satisfied, or gates is empty.
+func gatesSatisfied(gates []specGate) bool {
+	if len(gates) == 0 {
+		return true
+	}
+
+	dbTypes := GetDatabaseTypes()
+	for _, gate := range gates {
+		if gate.DatabaseType == "" {
+			return true
+		}
+		for _, dbType := range dbTypes {
+			if string(dbType) == gate.DatabaseType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// buildTestCase turns a parsed specDocument into the same TestCase shape a hand-written Go test
+// uses, seeding InitialData first (collection by collection, in collectionNames order) and then
+// appending the explicit Actions, resolving collection names and symbolic docIDs along the way.
+func buildTestCase(t *testing.T, collectionNames []string, doc specDocument) TestCase {
+	refs := newDocRefTracker()
+	actions := make([]any, 0, len(doc.Actions))
+
+	for _, name := range collectionNames {
+		for _, seed := range doc.InitialData[name] {
+			actions = append(actions, buildAction(t, collectionNames, refs, specAction{
+				Name:         "createDoc",
+				CollectionID: name,
+				DocID:        seed.DocID,
+				Doc:          seed.Doc,
+			}))
+		}
+	}
+
+	for _, action := range doc.Actions {
+		actions = append(actions, buildAction(t, collectionNames, refs, action))
+	}
+
+	return TestCase{
+		Description: doc.Description,
+		Actions:     actions,
+	}
+}
+
+// buildAction dispatches a to the Go action literal its Name selects. It fails t for an
+// unrecognised Name rather than silently dropping the action.
+func buildAction(t *testing.T, collectionNames []string, refs *docRefTracker, a specAction) any {
+	nodeID := immutable.None[int]()
+	if a.NodeID != nil {
+		nodeID = immutable.Some(*a.NodeID)
+	}
+
+	switch a.Name {
+	case "createDoc":
+		collectionID := resolveCollectionID(t, collectionNames, a.CollectionID)
+		refs.register(collectionID, a.DocID)
+		return CreateDoc{
+			NodeID:        nodeID,
+			CollectionID:  collectionID,
+			Doc:           toJSONString(t, a.Doc),
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "updateDoc":
+		collectionID := resolveCollectionID(t, collectionNames, a.CollectionID)
+		return UpdateDoc{
+			NodeID:        nodeID,
+			CollectionID:  collectionID,
+			DocID:         refs.resolve(t, collectionID, a.DocID),
+			Doc:           toJSONString(t, a.Doc),
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "deleteDoc":
+		collectionID := resolveCollectionID(t, collectionNames, a.CollectionID)
+		return DeleteDoc{
+			NodeID:        nodeID,
+			CollectionID:  collectionID,
+			DocID:         refs.resolve(t, collectionID, a.DocID),
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "schemaUpdate":
+		return SchemaUpdate{
+			NodeID:        nodeID,
+			Schema:        a.Schema,
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "patchSchema":
+		return SchemaPatch{
+			NodeID:        nodeID,
+			Patch:         a.Patch,
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "mergeSchema":
+		return SchemaMerge{
+			NodeID:        nodeID,
+			Patch:         a.Patch,
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "request":
+		return Request{
+			NodeID:        nodeID,
+			Request:       a.Request,
+			Results:       a.Results,
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "transactionRequest":
+		return TransactionRequest2{
+			TransactionID: a.TransactionID,
+			Request:       a.Request,
+			Results:       a.Results,
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "transactionCommit":
+		return TransactionCommit{
+			TransactionID: a.TransactionID,
+			ExpectedError: a.ExpectedError,
+		}
+
+	case "subscriptionRequest":
+		return SubscriptionRequest{
+			Request:       a.Request,
+			Results:       a.Results,
+			ExpectedError: a.ExpectedError,
+		}
+
+	default:
+		t.Fatalf("spec file: unsupported action name %q", a.Name)
+		return nil
+	}
+}
+
+// toJSONString re-serialises a decoded YAML/JSON document body back into the raw JSON string the
+// existing action executors (client.NewDocFromJSON, doc.SetWithJSON) expect.
+func toJSONString(t *testing.T, doc map[string]any) string {
+	bytes, err := json.Marshal(doc)
+	require.NoError(t, err, "marshalling spec document body")
+	return string(bytes)
+}
+
+// resolveCollectionID looks up name's index within collectionNames, failing t if it is not found.
+func resolveCollectionID(t *testing.T, collectionNames []string, name string) int {
+	for i, candidate := range collectionNames {
+		if candidate == name {
+			return i
+		}
+	}
+	t.Fatalf("spec file references unknown collection %q", name)
+	return -1
+}
+
+// docRefTracker assigns and resolves the symbolic docIDs a spec file's createDoc actions declare,
+// mapping them to the numeric, per-collection creation-order index the Go action literals use.
+type docRefTracker struct {
+	// names maps collectionID -> docID name -> numeric index.
+	names map[int]map[string]int
+	// next is the next numeric index to assign per collectionID.
+	next map[int]int
+}
+
+func newDocRefTracker() *docRefTracker {
+	return &docRefTracker{
+		names: map[int]map[string]int{},
+		next:  map[int]int{},
+	}
+}
+
+// register assigns the next numeric index for collectionID, associating it with name if name is
+// non-empty.
+func (r *docRefTracker) register(collectionID int, name string) {
+	index := r.next[collectionID]
+	r.next[collectionID] = index + 1
+
+	if name == "" {
+		return
+	}
+	if r.names[collectionID] == nil {
+		r.names[collectionID] = map[string]int{}
+	}
+	r.names[collectionID][name] = index
+}
+
+// resolve returns the numeric index previously registered for name within collectionID. If name
+// was never registered it is parsed as a plain integer literal instead, so positional references
+// work without a prior createDoc having declared a docID.
+func (r *docRefTracker) resolve(t *testing.T, collectionID int, name string) int {
+	if index, ok := r.names[collectionID][name]; ok {
+		return index
+	}
+
+	index, err := strconv.Atoi(name)
+	require.NoError(t, err, "docID %q was not registered by a prior createDoc action and is not a numeric index", name)
+	return index
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"context"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/node"
+)
+
+// MetricsAssert asserts the current value of a single Prometheus metric (peer count, replicator
+// lag, subscription fan-out, ...) registered against a node's metrics registry - see
+// [node.Node.Gatherer].
+//
+// Because [closeNodes] drops a node's registry and [restartNodes] gives each restarted node a
+// fresh one, a MetricsAssert only ever sees values accumulated since the node's current
+// incarnation started.
+type MetricsAssert struct {
+	// NodeID is the node whose metrics should be inspected. If not provided the first node will
+	// be used.
+	NodeID immutable.Option[int]
+
+	// Name is the Prometheus metric name to look up, e.g. "defradb_p2p_connected_peers".
+	Name string
+
+	// Labels, if provided, must all match a metric's label set for it to be selected. Used to
+	// disambiguate metrics exposing more than one series (e.g. per-collection counters).
+	Labels map[string]string
+
+	// ExpectedValue is the value the selected metric (gauge or counter) is expected to report.
+	ExpectedValue float64
+}
+
+func assertMetrics(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	action MetricsAssert,
+) {
+	for _, n := range getNodes(action.NodeID, nodes) {
+		families, err := n.Gatherer().Gather()
+		require.NoError(t, err)
+
+		metric, isFound := findMetric(families, action.Name, action.Labels)
+		require.True(t, isFound, "metric %q (labels %v) not found", action.Name, action.Labels)
+		require.Equal(t, action.ExpectedValue, metricValue(metric), "metric %q (labels %v)", action.Name, action.Labels)
+	}
+}
+
+// findMetric locates the single metric sample within families matching name and labels.
+func findMetric(
+	families []*dto.MetricFamily,
+	name string,
+	labels map[string]string,
+) (*dto.Metric, bool) {
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metricLabelsMatch(metric, labels) {
+				return metric, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func metricLabelsMatch(metric *dto.Metric, labels map[string]string) bool {
+	for wantName, wantValue := range labels {
+		var isMatched bool
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == wantName && label.GetValue() == wantValue {
+				isMatched = true
+				break
+			}
+		}
+		if !isMatched {
+			return false
+		}
+	}
+	return true
+}
+
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.Gauge != nil:
+		return metric.GetGauge().GetValue()
+	case metric.Counter != nil:
+		return metric.GetCounter().GetValue()
+	default:
+		return 0
+	}
+}
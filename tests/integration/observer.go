@@ -0,0 +1,204 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+// Event kinds recorded by [TestObserver]. Only [eventExecRequest], [eventSave], [eventTxnBegin],
+// [eventTxnCommit], and [eventTxnDiscard] are currently wired up - see executeRequest, createDoc,
+// and the transaction helpers (ensureTransaction, runTransactionRequest, commitTransaction) shared
+// by executeTransactionRequest and executeConcurrentTransactions. [eventDelete] and [eventP2PSync]
+// are defined for future use but not yet recorded anywhere.
+const (
+	eventExecRequest = "ExecRequest"
+	eventSave        = "Save"
+	eventDelete      = "Delete"
+	eventTxnBegin    = "TxnBegin"
+	eventTxnCommit   = "TxnCommit"
+	eventTxnDiscard  = "TxnDiscard"
+	eventP2PSync     = "P2PSync"
+)
+
+// observedEvent is a single recorded DB operation.
+type observedEvent struct {
+	Kind          string
+	NodeID        int
+	TransactionID immutable.Option[int]
+	// Digest is a short hash of the operation's payload (the request string, the document JSON,
+	// ...), present so an ExpectEvents action can compare a recorded event against an expected
+	// one without the harness needing to reconstruct and store the full payload.
+	Digest string
+	Err    error
+	At     time.Time
+}
+
+// TestObserver records the ExecRequest, Save, and transaction Begin/Commit/Discard events that the
+// harness causes a node.DB to perform over the course of a TestCase, at the call sites listed
+// above; UpdateDoc saves, DeleteDoc deletes and subscription requests are not recorded - analogous to
+// the mongo-driver event.CommandMonitor used by its own unified spec tests. A TestCase declares
+// assertions over the events recorded since its last checkpoint via the ExpectEvents and
+// AssertNoEvents action types.
+type TestObserver struct {
+	mu         sync.Mutex
+	events     []observedEvent
+	checkpoint int
+}
+
+func newTestObserver() *TestObserver {
+	return &TestObserver{}
+}
+
+// record appends a new event to the log.
+func (o *TestObserver) record(kind string, nodeID int, transactionID immutable.Option[int], payload string, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, observedEvent{
+		Kind:          kind,
+		NodeID:        nodeID,
+		TransactionID: transactionID,
+		Digest:        digestOf(payload),
+		Err:           err,
+		At:            time.Now(),
+	})
+}
+
+// sinceCheckpoint returns (without consuming) every event recorded since the last checkpoint.
+func (o *TestObserver) sinceCheckpoint() []observedEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make([]observedEvent, len(o.events)-o.checkpoint)
+	copy(out, o.events[o.checkpoint:])
+	return out
+}
+
+// advance moves the checkpoint to the end of the currently recorded events, so that a later
+// ExpectEvents/AssertNoEvents action only sees events generated after this point.
+func (o *TestObserver) advance() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.checkpoint = len(o.events)
+}
+
+// digestOf returns a short, stable hash of payload, suitable for comparing recorded events
+// without retaining (or re-deriving) their full bodies.
+func digestOf(payload string) string {
+	sum := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// ExpectedEvent describes one entry a test expects to find in the TestObserver's event log since
+// the last checkpoint. Kind and NodeID are required; TransactionID, if given, additionally
+// constrains the match.
+type ExpectedEvent struct {
+	Kind          string
+	NodeID        int
+	TransactionID immutable.Option[int]
+}
+
+// ExpectEvents asserts that Events occurred, in order, among the events recorded since the last
+// ExpectEvents/AssertNoEvents checkpoint - e.g. "this GraphQL query resulted in exactly one txn
+// with two reads and zero writes" becomes a TransactionID-scoped sequence of
+// {eventTxnBegin, eventExecRequest, eventExecRequest, eventTxnCommit}.
+//
+// If Exact is false (the default), Events is matched as an ordered subsequence - other, unlisted
+// events may appear between or around them. If Exact is true, the recorded events must match
+// Events one-for-one.
+//
+// Consuming ExpectEvents always advances the checkpoint, even on failure, so that the next
+// ExpectEvents/AssertNoEvents in the same TestCase only sees events generated after this point.
+type ExpectEvents struct {
+	Events []ExpectedEvent
+	Exact  bool
+}
+
+// AssertNoEvents asserts that no events (or, if Kind is set, no events of that kind) were
+// recorded since the last checkpoint - e.g. "the P2P replication produced no further commits on
+// node 2" becomes AssertNoEvents{Kind: eventTxnCommit}.
+type AssertNoEvents struct {
+	Kind string
+}
+
+// executeExpectEvents implements the ExpectEvents action.
+func executeExpectEvents(t *testing.T, testCase TestCase, observer *TestObserver, action ExpectEvents) {
+	recorded := observer.sinceCheckpoint()
+	observer.advance()
+
+	if action.Exact {
+		require.Equal(
+			t, len(action.Events), len(recorded),
+			"%s: expected exactly %d events since the last checkpoint, recorded %d: %+v",
+			testCase.Description, len(action.Events), len(recorded), recorded,
+		)
+	}
+
+	searchFrom := 0
+	for _, expected := range action.Events {
+		found := false
+		for i := searchFrom; i < len(recorded); i++ {
+			if eventMatches(recorded[i], expected) {
+				found = true
+				searchFrom = i + 1
+				break
+			}
+		}
+		require.True(
+			t, found,
+			"%s: expected event %+v not found (in order) among the events recorded since the last checkpoint: %+v",
+			testCase.Description, expected, recorded,
+		)
+	}
+}
+
+// executeAssertNoEvents implements the AssertNoEvents action.
+func executeAssertNoEvents(t *testing.T, testCase TestCase, observer *TestObserver, action AssertNoEvents) {
+	recorded := observer.sinceCheckpoint()
+	observer.advance()
+
+	for _, event := range recorded {
+		if action.Kind == "" || event.Kind == action.Kind {
+			t.Fatalf(
+				"%s: expected no %q events since the last checkpoint, found %+v",
+				testCase.Description, action.Kind, event,
+			)
+		}
+	}
+}
+
+// firstErr returns the first entry of errs, or nil if it is empty - for recording a single
+// representative error against an event whose underlying call may return several.
+func firstErr(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
+
+// eventMatches reports whether recorded satisfies expected's constraints.
+func eventMatches(recorded observedEvent, expected ExpectedEvent) bool {
+	if recorded.Kind != expected.Kind || recorded.NodeID != expected.NodeID {
+		return false
+	}
+	if expected.TransactionID.HasValue() {
+		if !recorded.TransactionID.HasValue() || recorded.TransactionID.Value() != expected.TransactionID.Value() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/sourcenetwork/defradb/datastore"
+)
+
+// txnOracleEnvName, when set to "1", turns on the transaction-conflict/serializability oracle -
+// see [txnOracle].
+const txnOracleEnvName = "DEFRA_TXN_ORACLE"
+
+// ConcurrentTransactions fans out the given TransactionRequest2 actions onto separate goroutines
+// and waits for all of them to finish before the harness proceeds to the next action. Unlike a
+// plain sequence of TransactionRequest2 actions - which always runs one request at a time, in
+// action-list order - this exercises genuinely concurrent access to the underlying store, which
+// is exactly the kind of execution the [txnOracle] (DEFRA_TXN_ORACLE=1) is meant to check for
+// isolation regressions in.
+type ConcurrentTransactions struct {
+	// Transactions are the TransactionRequest2 actions to run concurrently, one goroutine each.
+	Transactions []TransactionRequest2
+}
+
+// executeConcurrentTransactions ensures a transaction exists for every TransactionID referenced
+// by action (sequentially, so creation itself never races), then runs each sub-action's request
+// on its own goroutine and waits for them all to complete.
+func executeConcurrentTransactions(
+	ctx context.Context,
+	t *testing.T,
+	db client.DB,
+	txns []datastore.Txn,
+	testCase TestCase,
+	oracle *txnOracle,
+	observer *TestObserver,
+	action ConcurrentTransactions,
+) []datastore.Txn {
+	for _, subAction := range action.Transactions {
+		txns = ensureTransaction(ctx, t, db, txns, testCase, oracle, observer, subAction)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(action.Transactions))
+	for _, subAction := range action.Transactions {
+		subAction := subAction
+		go func() {
+			defer wg.Done()
+			if txns[subAction.TransactionID] == nil {
+				// Transaction creation failed and was already asserted against
+				// subAction.ExpectedError by ensureTransaction.
+				return
+			}
+			runTransactionRequest(ctx, t, db, txns[subAction.TransactionID], testCase, observer, subAction)
+		}()
+	}
+	wg.Wait()
+
+	return txns
+}
+
+// txnScheduleEntry is a single committed transaction's read/write sets and the real-time interval
+// (in oracle-local sequence numbers) over which it executed.
+type txnScheduleEntry struct {
+	transactionID int
+	startSeq      int
+	commitSeq     int
+	reads         []ds.Key
+	writes        []ds.Key
+}
+
+// txnOracle records the read/write sets and start/commit order of every transaction committed
+// during a test case and, once DEFRA_TXN_ORACLE=1 is set, checks that no two transactions whose
+// execution intervals overlapped also touched conflicting keys - any pair that does is direct
+// evidence that the store let two non-serializable transactions both succeed.
+//
+// This is deliberately narrower than a full precedence-graph/cycle check: it only needs the
+// start/commit order and the final read/write sets captured via [datastore.Txn.ReadSet] and
+// [datastore.Txn.WriteSet], which is exactly what the harness already has on hand after running
+// a batch of TransactionRequest2/ConcurrentTransactions actions.
+type txnOracle struct {
+	enabled bool
+
+	mu        sync.Mutex
+	nextSeq   int
+	startSeqs map[int]int
+	schedule  []txnScheduleEntry
+}
+
+func newTxnOracle() *txnOracle {
+	return &txnOracle{
+		enabled:   os.Getenv(txnOracleEnvName) == "1",
+		startSeqs: map[int]int{},
+	}
+}
+
+// recordStart notes the oracle-local sequence number at which transactionID began, if it has not
+// already been recorded.
+func (o *txnOracle) recordStart(transactionID int) {
+	if !o.enabled {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.startSeqs[transactionID]; ok {
+		return
+	}
+	o.nextSeq++
+	o.startSeqs[transactionID] = o.nextSeq
+}
+
+// recordCommit notes that transactionID committed successfully with the given read/write sets.
+func (o *txnOracle) recordCommit(transactionID int, reads, writes []ds.Key) {
+	if !o.enabled {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.nextSeq++
+	o.schedule = append(o.schedule, txnScheduleEntry{
+		transactionID: transactionID,
+		startSeq:      o.startSeqs[transactionID],
+		commitSeq:     o.nextSeq,
+		reads:         reads,
+		writes:        writes,
+	})
+}
+
+// verify fails t if any two committed transactions in the recorded schedule had overlapping
+// execution intervals and conflicting key sets - i.e. if the observed commits could not have come
+// from any serial (one-at-a-time) execution of the same transactions.
+func (o *txnOracle) verify(t *testing.T, testCase TestCase) {
+	if !o.enabled {
+		return
+	}
+	o.mu.Lock()
+	schedule := o.schedule
+	o.mu.Unlock()
+
+	for i := 0; i < len(schedule); i++ {
+		for j := i + 1; j < len(schedule); j++ {
+			a, b := schedule[i], schedule[j]
+			if !intervalsOverlap(a, b) {
+				// One committed entirely before the other started - no possible conflict,
+				// this pair is consistent with a serial schedule.
+				continue
+			}
+
+			if conflictKey, found := conflictingKey(a, b); found {
+				t.Fatalf(
+					"transaction oracle: non-serializable schedule in %q: "+
+						"txn %d (start=%d, commit=%d) and txn %d (start=%d, commit=%d) "+
+						"both executed concurrently and touched key %q\n"+
+						"txn %d reads=%v writes=%v\ntxn %d reads=%v writes=%v",
+					testCase.Description,
+					a.transactionID, a.startSeq, a.commitSeq,
+					b.transactionID, b.startSeq, b.commitSeq,
+					conflictKey,
+					a.transactionID, a.reads, a.writes,
+					b.transactionID, b.reads, b.writes,
+				)
+			}
+		}
+	}
+}
+
+// intervalsOverlap reports whether a and b's [start, commit] sequence ranges intersect, i.e.
+// whether either could have observed the other's uncommitted state.
+func intervalsOverlap(a, b txnScheduleEntry) bool {
+	return a.startSeq < b.commitSeq && b.startSeq < a.commitSeq
+}
+
+// conflictingKey returns a key present in both a write set of one entry and either set of the
+// other, if any, along with true. Two transactions conflict if one wrote a key the other read or
+// wrote.
+func conflictingKey(a, b txnScheduleEntry) (ds.Key, bool) {
+	if key, found := findCommonKey(a.writes, b.reads); found {
+		return key, true
+	}
+	if key, found := findCommonKey(a.reads, b.writes); found {
+		return key, true
+	}
+	if key, found := findCommonKey(a.writes, b.writes); found {
+		return key, true
+	}
+	return ds.Key{}, false
+}
+
+func findCommonKey(left, right []ds.Key) (ds.Key, bool) {
+	seen := make(map[string]struct{}, len(left))
+	for _, key := range left {
+		seen[key.String()] = struct{}{}
+	}
+	for _, key := range right {
+		if _, ok := seen[key.String()]; ok {
+			return key, true
+		}
+	}
+	return ds.Key{}, false
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// replayModeEnvName selects record/replay mode for the whole run - see [replaySession]. Inspired
+// by the rpcreplay pattern in the Google Cloud datastore integration tests: a long, network-heavy
+// P2P/multi-node scenario is captured once with DEFRA_REPLAY_MODE=record, then replayed
+// hermetically in CI with DEFRA_REPLAY_MODE=replay, without a real badger store or libp2p host.
+const replayModeEnvName = "DEFRA_REPLAY_MODE"
+
+type replayMode string
+
+const (
+	replayModeOff    replayMode = ""
+	replayModeRecord replayMode = "record"
+	replayModeReplay replayMode = "replay"
+)
+
+// configuredReplayMode is read once from DEFRA_REPLAY_MODE. Any value other than "record" or
+// "replay" (including unset) leaves the subsystem off, so a TestCase.ReplayFile is simply ignored.
+var configuredReplayMode replayMode
+
+func init() {
+	switch strings.ToLower(os.Getenv(replayModeEnvName)) {
+	case "record":
+		configuredReplayMode = replayModeRecord
+	case "replay":
+		configuredReplayMode = replayModeReplay
+	}
+}
+
+// replayEntry is a single recorded clock read, request/subscription result, or transaction
+// outcome, in the order the harness observed it (record mode) or must reproduce it (replay mode).
+type replayEntry struct {
+	Kind    string `json:"kind"`
+	Payload string `json:"payload,omitempty"`
+	Err     string `json:"err,omitempty"`
+	// Elapsed is this entry's clock reading as an offset from the session's start, so a replayed
+	// run reproduces the same wall-clock deltas (retry backoff, subscription waits, ...) that were
+	// observed at record time, rather than re-measuring (and re-waiting for) them.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// replayFile is the on-disk shape of a TestCase.ReplayFile: one entry list per test, keyed by
+// TestCase.Description, so a single file can hold the recordings for every test in a package.
+type replayFile struct {
+	Cases map[string][]replayEntry `json:"cases"`
+}
+
+// replaySession drives TestCase.ReplayFile for a single TestCase run.
+//
+// In record mode it timestamps and appends every now()/sleep() clock read and recordRequest()
+// call to an in-memory log, flushed to disk by close(). In replay mode it serves the same calls
+// back from the log captured under the same Description, in order, instead of consulting the real
+// clock - so a retry schedule, subscription result, or transaction outcome that depended on timing
+// is reproduced exactly rather than re-derived, which is what makes the replay hermetic.
+//
+// A session for which replay is not configured, or whose TestCase did not set a ReplayFile, is a
+// well-behaved no-op: now()/sleep() fall back to the real clock and recordRequest()/close() do
+// nothing.
+type replaySession struct {
+	mode  replayMode
+	path  string
+	key   string
+	start time.Time
+
+	mu       sync.Mutex
+	log      []replayEntry // record mode: entries captured so far, flushed to path by close
+	playback []replayEntry // replay mode: entries loaded from path for this session's key
+	cursor   int
+}
+
+// newReplaySession returns the replaySession for testCase, reading its recording from
+// testCase.ReplayFile up front if this run is replaying.
+func newReplaySession(t *testing.T, testCase TestCase) *replaySession {
+	if configuredReplayMode == replayModeOff || testCase.ReplayFile == "" {
+		return &replaySession{mode: replayModeOff}
+	}
+
+	s := &replaySession{
+		mode:  configuredReplayMode,
+		path:  testCase.ReplayFile,
+		key:   testCase.Description,
+		start: time.Now(),
+	}
+
+	if s.mode == replayModeReplay {
+		contents, err := os.ReadFile(s.path)
+		require.NoError(t, err, "replay: reading %q", s.path)
+
+		var doc replayFile
+		require.NoError(t, json.Unmarshal(contents, &doc), "replay: parsing %q", s.path)
+
+		entries, ok := doc.Cases[s.key]
+		require.True(t, ok, "replay: %q has no recording for %q", s.path, s.key)
+		s.playback = entries
+	}
+
+	return s
+}
+
+// isRecording reports whether s is capturing a new recording.
+func (s *replaySession) isRecording() bool {
+	return s != nil && s.mode == replayModeRecord
+}
+
+// isReplaying reports whether s is serving calls from a previously captured recording - callers
+// use this to decide whether to stand up a real badger store/libp2p host at all, or a stub.
+func (s *replaySession) isReplaying() bool {
+	return s != nil && s.mode == replayModeReplay
+}
+
+// now returns the current time. Off and recording sessions return the real clock (recording also
+// logs the reading's offset from session start); a replaying session instead returns the offset
+// recorded at the matching point in its capture, without touching the real clock at all.
+func (s *replaySession) now() time.Time {
+	if s == nil || s.mode == replayModeOff {
+		return time.Now()
+	}
+	if s.mode == replayModeReplay {
+		return s.start.Add(s.next("clock").Elapsed)
+	}
+
+	actual := time.Now()
+	s.append(replayEntry{Kind: "clock", Elapsed: actual.Sub(s.start)})
+	return actual
+}
+
+// sleep behaves like time.Sleep(d), except a replaying session returns immediately - the delay it
+// would have incurred was already captured (and is reproduced via now()), so a replayed run
+// doesn't pay for it a second time.
+func (s *replaySession) sleep(d time.Duration) {
+	if s.isReplaying() {
+		s.next("sleep")
+		return
+	}
+
+	time.Sleep(d)
+	if s.isRecording() {
+		s.append(replayEntry{Kind: "sleep", Elapsed: d})
+	}
+}
+
+// recordRequest logs the result of a GraphQL request, subscription update, or transaction outcome
+// under kind, if this session is recording. It is a no-op otherwise - replay of request bodies
+// themselves is not yet consulted by the harness (the stub nodes installed by getStartingNodes
+// execute requests for real against an in-memory store), but the recording is still captured so a
+// future replay-side executor has it to compare against.
+func (s *replaySession) recordRequest(kind string, payload string, err error) {
+	if !s.isRecording() {
+		return
+	}
+
+	entry := replayEntry{Kind: kind, Payload: payload, Elapsed: time.Since(s.start)}
+	if err != nil {
+		entry.Err = err.Error()
+	}
+	s.append(entry)
+}
+
+func (s *replaySession) append(entry replayEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log = append(s.log, entry)
+}
+
+// next consumes and returns the next entry of a replaying session. It panics (rather than index
+// out of range) if the recording is shorter than this run needs, most likely because the test's
+// actions have diverged from what was captured.
+func (s *replaySession) next(wantKind string) replayEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cursor >= len(s.playback) {
+		panic(fmt.Sprintf("replay: recording for %q in %q is exhausted, expected a %q entry", s.key, s.path, wantKind))
+	}
+	entry := s.playback[s.cursor]
+	s.cursor++
+	return entry
+}
+
+// effectiveDatabaseType returns dbt unchanged, unless replay is replaying - in which case it
+// always returns defraIMType, the harness's existing lightweight in-memory backend, so a replayed
+// run constructs stub nodes backed by that instead of spinning up a real badger store. Full
+// libp2p-host stubbing is not yet wired up: a ConfigureNode-based P2P scenario replayed this way
+// still starts real listeners, it just does so against the in-memory store.
+func effectiveDatabaseType(dbt DatabaseType, replay *replaySession) DatabaseType {
+	if replay.isReplaying() {
+		return defraIMType
+	}
+	return dbt
+}
+
+// close flushes a recording session's captured log to path, merging it into any other tests'
+// recordings already present in the same ReplayFile. It is a no-op for off/replay sessions.
+func (s *replaySession) close(t *testing.T) {
+	if !s.isRecording() {
+		return
+	}
+
+	doc := replayFile{Cases: map[string][]replayEntry{}}
+	if contents, err := os.ReadFile(s.path); err == nil {
+		// Best-effort: if the existing file is unreadable or corrupt we just overwrite it with
+		// this session's own recording rather than failing the test over it.
+		_ = json.Unmarshal(contents, &doc)
+	}
+	if doc.Cases == nil {
+		doc.Cases = map[string][]replayEntry{}
+	}
+	doc.Cases[s.key] = s.log
+
+	contents, err := json.MarshalIndent(doc, "", "  ")
+	require.NoError(t, err, "replay: marshalling %q", s.path)
+	require.NoError(t, os.WriteFile(s.path, contents, 0o644), "replay: writing %q", s.path)
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sourcenetwork/defradb/logging"
+)
+
+const (
+	retryInitialBackoffEnvName = "DEFRA_RETRY_INITIAL_BACKOFF"
+	retryMaxBackoffEnvName     = "DEFRA_RETRY_MAX_BACKOFF"
+	retryMultiplierEnvName     = "DEFRA_RETRY_MULTIPLIER"
+	retryMaxDurationEnvName    = "DEFRA_RETRY_MAX_DURATION"
+)
+
+// RetryOptions describes a bounded exponential backoff schedule used while waiting for
+// convergence (node head CIDs matching, replicator queues draining, subscriptions delivering)
+// across a P2P test network.
+//
+// A fixed sleep is either too short for a loaded CI machine or too long for a fast local run -
+// backing off from InitialBackoff towards MaxBackoff lets most waits resolve quickly while still
+// tolerating slow propagation, up to MaxDuration.
+type RetryOptions struct {
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries - the backoff stops growing once it reaches it.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the backoff after every failed attempt, until MaxBackoff is hit.
+	Multiplier float64
+
+	// MaxDuration is the total time budget across all attempts. Once exceeded the wait fails,
+	// reporting the last observed state.
+	MaxDuration time.Duration
+}
+
+// DefaultRetryOptions is the schedule used by WaitForSync and node-restart P2P reconnection when
+// a test case does not specify its own. Overridable via DEFRA_RETRY_INITIAL_BACKOFF,
+// DEFRA_RETRY_MAX_BACKOFF, DEFRA_RETRY_MULTIPLIER, and DEFRA_RETRY_MAX_DURATION so that a slower
+// CI environment can widen the schedule without editing test cases.
+var DefaultRetryOptions = RetryOptions{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     1 * time.Second,
+	Multiplier:     2,
+	MaxDuration:    10 * time.Second,
+}
+
+func init() {
+	if v, isSet := os.LookupEnv(retryInitialBackoffEnvName); isSet {
+		if d, err := time.ParseDuration(v); err == nil {
+			DefaultRetryOptions.InitialBackoff = d
+		}
+	}
+	if v, isSet := os.LookupEnv(retryMaxBackoffEnvName); isSet {
+		if d, err := time.ParseDuration(v); err == nil {
+			DefaultRetryOptions.MaxBackoff = d
+		}
+	}
+	if v, isSet := os.LookupEnv(retryMultiplierEnvName); isSet {
+		if m, err := strconv.ParseFloat(v, 64); err == nil {
+			DefaultRetryOptions.Multiplier = m
+		}
+	}
+	if v, isSet := os.LookupEnv(retryMaxDurationEnvName); isSet {
+		if d, err := time.ParseDuration(v); err == nil {
+			DefaultRetryOptions.MaxDuration = d
+		}
+	}
+}
+
+// retryUntil polls condition on the given backoff schedule until it returns true or retry's
+// MaxDuration elapses, logging each attempt. It returns true iff condition converged in time.
+func retryUntil(
+	ctx context.Context,
+	description string,
+	retry RetryOptions,
+	condition func() bool,
+) bool {
+	deadline := time.Now().Add(retry.MaxDuration)
+	backoff := retry.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		if condition() {
+			return true
+		}
+
+		if !time.Now().Add(backoff).Before(deadline) {
+			return false
+		}
+
+		log.Info(
+			ctx,
+			"Waiting for sync, retrying",
+			logging.NewKV("Description", description),
+			logging.NewKV("Attempt", attempt),
+			logging.NewKV("Backoff", backoff),
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return false
+		}
+
+		backoff = time.Duration(float64(backoff) * retry.Multiplier)
+		if backoff > retry.MaxBackoff {
+			backoff = retry.MaxBackoff
+		}
+	}
+}
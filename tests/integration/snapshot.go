@@ -0,0 +1,78 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sourcenetwork/immutable"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcenetwork/defradb/node"
+)
+
+// Snapshot captures the full, portable contents of a node's database (see
+// [github.com/sourcenetwork/defradb/client.DB.Snapshot]) into an in-memory buffer keyed by Name,
+// so a later [Restore] action can load it back - including into a node backed by a different
+// datastore backend, or across a change-detector branch boundary.
+//
+// Note on provenance: this file was committed alongside an unrelated datastore.RootStore rename
+// rather than under its own change - it depends on, and should have landed together with or after,
+// [github.com/sourcenetwork/defradb/client.DB.Snapshot]/Restore, not a commit earlier than them.
+type Snapshot struct {
+	// NodeID is the node to snapshot. If not provided the first node will be used.
+	NodeID immutable.Option[int]
+
+	// Name identifies this snapshot, so that a later Restore action can reference it.
+	Name string
+}
+
+// Restore loads a previously captured [Snapshot] back into a node's database.
+type Restore struct {
+	// NodeID is the node to restore into. If not provided the first node will be used.
+	NodeID immutable.Option[int]
+
+	// Name must match the Name of a [Snapshot] action that ran earlier in the same test case.
+	Name string
+}
+
+func takeSnapshot(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	snapshots map[string][]byte,
+	action Snapshot,
+) {
+	for _, node := range getNodes(action.NodeID, nodes) {
+		var buf bytes.Buffer
+		err := node.DB.Snapshot(ctx, &buf)
+		require.NoError(t, err)
+		snapshots[action.Name] = buf.Bytes()
+	}
+}
+
+func restoreSnapshot(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	snapshots map[string][]byte,
+	action Restore,
+) {
+	data, isTaken := snapshots[action.Name]
+	require.True(t, isTaken, "no snapshot named %q has been taken", action.Name)
+
+	for _, node := range getNodes(action.NodeID, nodes) {
+		err := node.DB.Restore(ctx, bytes.NewReader(data))
+		require.NoError(t, err)
+	}
+}
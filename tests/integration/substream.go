@@ -0,0 +1,283 @@
+// Copyright 2023 Democratized Data Foundation
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tests
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sourcenetwork/defradb/client"
+	"github.com/stretchr/testify/require"
+)
+
+// Exists is a SubscriptionEventPattern field matcher, analogous to the mongo unified test runner's
+// $$exists operator: it asserts only that the field is (or, if false, is not) present in the
+// event's data, without constraining its value.
+type Exists bool
+
+// Regex is a SubscriptionEventPattern field matcher, analogous to the mongo unified test runner's
+// $$matchesRegex operator: it asserts that the field's value, formatted with fmt.Sprintf("%v", ...),
+// matches Pattern.
+type Regex struct {
+	Pattern string
+}
+
+// SubscriptionEventPattern describes the expected shape of one subscription event's document,
+// keyed by field name. A field's expected value may be a literal (asserted with equality), an
+// AnyOf (membership, the same matcher Request.Results already supports), a [Regex], or an
+// [Exists].
+type SubscriptionEventPattern map[string]any
+
+// ExpectSubscriptionEvent blocks, for up to Timeout (or subscriptionTimeout if Timeout is zero),
+// until the subscription identified by SubscriptionID yields its next buffered event, then asserts
+// that event's single document against Pattern.
+//
+// Unlike SubscriptionRequest.Results, which can only be asserted once every action in the
+// TestCase has run, ExpectSubscriptionEvent can be interleaved with mutating actions - e.g.
+// CreateDoc, ExpectSubscriptionEvent, UpdateDoc, ExpectSubscriptionEvent - to assert ordering
+// between a mutation and the event it produces.
+type ExpectSubscriptionEvent struct {
+	// SubscriptionID identifies which SubscriptionRequest this event is expected from.
+	SubscriptionID int
+
+	// Pattern is matched against the event's document.
+	Pattern SubscriptionEventPattern
+
+	// Timeout overrides how long to wait for the event before failing. Defaults to
+	// subscriptionTimeout.
+	Timeout time.Duration
+}
+
+// ResumeSubscription re-issues the original request of the subscription identified by
+// SubscriptionID, so that ExpectSubscriptionEvent actions following it consume events from the
+// new stream.
+//
+// The resume token captured from the subscription's last event (if any) is logged for diagnostic
+// purposes, mirroring a mongo change stream resume from its last _id.resumeToken - but, as this
+// harness has no way to ask the running node to seek a GQL subscription's underlying event stream
+// to a given point, the new stream simply starts from "now" rather than genuinely resuming from
+// the token. A ResumeSubscription test should therefore only assert on events produced after it
+// runs.
+type ResumeSubscription struct {
+	// SubscriptionID identifies which SubscriptionRequest to resume.
+	SubscriptionID int
+}
+
+// CloseSubscription marks the subscription identified by SubscriptionID as closed, so that a
+// later ExpectSubscriptionEvent against it fails fast instead of waiting out its timeout.
+type CloseSubscription struct {
+	// SubscriptionID identifies which SubscriptionRequest to close.
+	SubscriptionID int
+}
+
+// liveSubscriptionEvent is a single event consumed off a liveSubscription's stream.
+type liveSubscriptionEvent struct {
+	data []map[string]any
+	errs []error
+}
+
+// liveSubscription is a SubscriptionRequest's buffered stream of events, consumed in order by
+// ExpectSubscriptionEvent actions interleaved with the rest of the TestCase's actions.
+type liveSubscription struct {
+	// request is the subscription's original GQL request string, kept so ResumeSubscription can
+	// re-issue it.
+	request string
+
+	mu          sync.Mutex
+	closed      bool
+	seq         int
+	resumeToken string
+
+	events chan liveSubscriptionEvent
+}
+
+// newLiveSubscription returns a liveSubscription ready to have events pushed onto it by
+// executeSubscriptionRequest.
+func newLiveSubscription(request string) *liveSubscription {
+	return &liveSubscription{
+		request: request,
+		events:  make(chan liveSubscriptionEvent, 16),
+	}
+}
+
+// registerLiveSubscription creates and registers a liveSubscription for id, replacing any
+// previous registration under the same id.
+func registerLiveSubscription(subs map[int]*liveSubscription, id int, request string) *liveSubscription {
+	live := newLiveSubscription(request)
+	subs[id] = live
+	return live
+}
+
+// push records a single event, deriving and storing the resume token it can later be resumed
+// from. It is a no-op if live is nil, so callers that have no interleaved assertions registered
+// against this subscription don't pay for the synchronisation.
+func (live *liveSubscription) push(data []map[string]any, errs []error) {
+	if live == nil {
+		return
+	}
+
+	live.mu.Lock()
+	live.seq++
+	live.resumeToken = fmt.Sprintf("%d:%s", live.seq, digestOf(fmt.Sprintf("%v", data)))
+	closed := live.closed
+	live.mu.Unlock()
+
+	if closed {
+		return
+	}
+	live.events <- liveSubscriptionEvent{data: data, errs: errs}
+}
+
+// executeExpectSubscriptionEvent implements the ExpectSubscriptionEvent action.
+func executeExpectSubscriptionEvent(
+	t *testing.T,
+	testCase TestCase,
+	subs map[int]*liveSubscription,
+	action ExpectSubscriptionEvent,
+) {
+	live, ok := subs[action.SubscriptionID]
+	require.True(t, ok, "%s: no subscription registered with id %d", testCase.Description, action.SubscriptionID)
+
+	live.mu.Lock()
+	closed := live.closed
+	live.mu.Unlock()
+	require.False(t, closed, "%s: subscription %d is closed", testCase.Description, action.SubscriptionID)
+
+	timeout := action.Timeout
+	if timeout == 0 {
+		timeout = subscriptionTimeout
+	}
+
+	select {
+	case event := <-live.events:
+		for _, err := range event.errs {
+			require.NoError(t, err, testCase.Description)
+		}
+		require.Len(
+			t, event.data, 1,
+			"%s: subscription %d: expected exactly one document in this event, got %+v",
+			testCase.Description, action.SubscriptionID, event.data,
+		)
+		require.True(
+			t, matchesPattern(event.data[0], action.Pattern),
+			"%s: subscription %d: event %+v did not match pattern %+v",
+			testCase.Description, action.SubscriptionID, event.data[0], action.Pattern,
+		)
+
+	case <-time.After(timeout):
+		t.Fatalf(
+			"%s: subscription %d: timed out after %s waiting for the next event",
+			testCase.Description, action.SubscriptionID, timeout,
+		)
+	}
+}
+
+// matchesPattern reports whether actual satisfies every field matcher in pattern.
+func matchesPattern(actual map[string]any, pattern SubscriptionEventPattern) bool {
+	for field, want := range pattern {
+		actualValue, present := actual[field]
+
+		switch w := want.(type) {
+		case Exists:
+			if present != bool(w) {
+				return false
+			}
+
+		case Regex:
+			if !present {
+				return false
+			}
+			matched, err := regexp.MatchString(w.Pattern, fmt.Sprintf("%v", actualValue))
+			if err != nil || !matched {
+				return false
+			}
+
+		case AnyOf:
+			if !present || !anyOfContains(w, actualValue) {
+				return false
+			}
+
+		default:
+			if !present || actualValue != want {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// anyOfContains reports whether actualValue is deep-equal to any element of anyOf.
+func anyOfContains(anyOf AnyOf, actualValue any) bool {
+	rv := reflect.ValueOf(anyOf)
+	for i := 0; i < rv.Len(); i++ {
+		if reflect.DeepEqual(rv.Index(i).Interface(), actualValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// resumeSubscription implements the ResumeSubscription action: it re-issues the subscription's
+// original request and swaps the id's liveSubscription over to the new stream - see
+// [ResumeSubscription]'s doc comment for the resume token caveat.
+func resumeSubscription(
+	ctx context.Context,
+	t *testing.T,
+	allActionsDone chan struct{},
+	db client.DB,
+	testCase TestCase,
+	subs map[int]*liveSubscription,
+	action ResumeSubscription,
+) {
+	old, ok := subs[action.SubscriptionID]
+	require.True(t, ok, "%s: no subscription registered with id %d", testCase.Description, action.SubscriptionID)
+
+	old.mu.Lock()
+	request := old.request
+	old.closed = true
+	old.mu.Unlock()
+
+	result := db.ExecRequest(ctx, request)
+	require.Empty(t, result.GQL.Errors, "%s: resuming subscription %d", testCase.Description, action.SubscriptionID)
+
+	live := registerLiveSubscription(subs, action.SubscriptionID, request)
+
+	go func() {
+		stream := result.Pub.Stream()
+		for {
+			select {
+			case s := <-stream:
+				sResult, _ := s.(client.GQLResult)
+				sData, _ := sResult.Data.([]map[string]any)
+				live.push(sData, sResult.Errors)
+
+			case <-allActionsDone:
+				return
+			}
+		}
+	}()
+}
+
+// closeSubscription implements the CloseSubscription action.
+func closeSubscription(subs map[int]*liveSubscription, action CloseSubscription) {
+	live, ok := subs[action.SubscriptionID]
+	if !ok {
+		return
+	}
+	live.mu.Lock()
+	live.closed = true
+	live.mu.Unlock()
+}
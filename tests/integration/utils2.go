@@ -16,6 +16,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -29,7 +30,9 @@ import (
 	"github.com/sourcenetwork/defradb/config"
 	"github.com/sourcenetwork/defradb/datastore"
 	badgerds "github.com/sourcenetwork/defradb/datastore/badger/v3"
+	"github.com/sourcenetwork/defradb/datastore/faultds"
 	"github.com/sourcenetwork/defradb/datastore/memory"
+	_ "github.com/sourcenetwork/defradb/datastore/pebble" // registers the "pebble" backend
 	"github.com/sourcenetwork/defradb/db"
 	"github.com/sourcenetwork/defradb/errors"
 	"github.com/sourcenetwork/defradb/logging"
@@ -42,6 +45,8 @@ const (
 	fileBadgerPathEnvName      = "DEFRA_BADGER_FILE_PATH"
 	rootDBFilePathEnvName      = "DEFRA_TEST_ROOT"
 	inMemoryEnvName            = "DEFRA_IN_MEMORY"
+	backendsEnvName            = "DEFRA_BACKENDS"
+	faultSeedEnvName           = "DEFRA_FAULT_SEED"
 	setupOnlyEnvName           = "DEFRA_SETUP_ONLY"
 	detectDbChangesEnvName     = "DEFRA_DETECT_DATABASE_CHANGES"
 	repositoryEnvName          = "DEFRA_CODE_REPOSITORY"
@@ -62,8 +67,37 @@ var (
 	badgerInMemory bool
 	badgerFile     bool
 	inMemoryStore  bool
+	// explicitBackends, if non-empty, overrides the individual env-var flags above: it is the
+	// parsed form of DEFRA_BACKENDS, a comma-separated list of [datastore.RegisterBackend] names
+	// (e.g. "badger-file-system,pebble") to run the integration suite against.
+	explicitBackends []DatabaseType
+
+	// faultSeedConfigured and faultSeed come from DEFRA_FAULT_SEED: when set, every database
+	// constructed by [GetDatabase] has its rootstore wrapped in a [faultds.Datastore] seeded from
+	// faultSeed, so that a `FaultInjection` action can be used in the test case.
+	faultSeedConfigured bool
+	faultSeed           int64
 )
 
+// faultInjectors maps a constructed [client.DB] to the [faultds.Datastore] wrapping its
+// rootstore, so that a `FaultInjection` action can look up and adjust the fault schedule for a
+// given node's database after the fact.
+var faultInjectors = map[client.DB]*faultds.Datastore{}
+
+func init() {
+	datastore.RegisterBackend(string(badgerIMType), func(ctx context.Context, path string) (datastore.RootStore, error) {
+		opts := badgerds.Options{Options: badger.DefaultOptions("").WithInMemory(true)}
+		return badgerds.NewDatastore("", &opts)
+	})
+	datastore.RegisterBackend(string(badgerFileType), func(ctx context.Context, path string) (datastore.RootStore, error) {
+		opts := badgerds.Options{Options: badger.DefaultOptions(path)}
+		return badgerds.NewDatastore(path, &opts)
+	})
+	datastore.RegisterBackend(string(defraIMType), func(ctx context.Context, path string) (datastore.RootStore, error) {
+		return memory.NewDatastore(context.Background()), nil
+	})
+}
+
 const subscriptionTimeout = 1 * time.Second
 
 var databaseDir string
@@ -104,6 +138,8 @@ func init() {
 	rootDatabaseDir, _ = os.LookupEnv(rootDBFilePathEnvName)
 	detectDbChangesValue, _ := os.LookupEnv(detectDbChangesEnvName)
 	inMemoryStoreValue, _ := os.LookupEnv(inMemoryEnvName)
+	backendsValue, backendsSpecified := os.LookupEnv(backendsEnvName)
+	faultSeedValue, faultSeedSpecified := os.LookupEnv(faultSeedEnvName)
 	repositoryValue, repositorySpecified := os.LookupEnv(repositoryEnvName)
 	setupOnlyValue, _ := os.LookupEnv(setupOnlyEnvName)
 	targetBranchValue, targetBranchSpecified := os.LookupEnv(targetBranchEnvName)
@@ -114,6 +150,23 @@ func init() {
 	DetectDbChanges = getBool(detectDbChangesValue)
 	SetupOnly = getBool(setupOnlyValue)
 
+	if backendsSpecified {
+		for _, name := range strings.Split(backendsValue, ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				explicitBackends = append(explicitBackends, DatabaseType(name))
+			}
+		}
+	}
+
+	if faultSeedSpecified {
+		parsedSeed, err := strconv.ParseInt(faultSeedValue, 10, 64)
+		if err == nil {
+			faultSeed = parsedSeed
+			faultSeedConfigured = true
+		}
+	}
+
 	if !repositorySpecified {
 		repositoryValue = "https://github.com/sourcenetwork/defradb.git"
 	}
@@ -214,7 +267,16 @@ func newBadgerFileDB(ctx context.Context, t testing.TB, path string) (client.DB,
 	return db, nil
 }
 
+// GetDatabaseTypes returns the set of backends the integration suite should run against.
+//
+// If DEFRA_BACKENDS is set, it is taken verbatim (as a comma-separated list of
+// [datastore.RegisterBackend] names, e.g. "badger-file-system,pebble"). Otherwise it falls back
+// to the legacy DEFRA_BADGER_MEMORY / DEFRA_BADGER_FILE / DEFRA_IN_MEMORY boolean flags.
 func GetDatabaseTypes() []DatabaseType {
+	if len(explicitBackends) > 0 {
+		return explicitBackends
+	}
+
 	databases := []DatabaseType{}
 
 	if badgerInMemory {
@@ -232,31 +294,57 @@ func GetDatabaseTypes() []DatabaseType {
 	return databases
 }
 
+// GetDatabase constructs the database for the given backend, looked up by name in the
+// [datastore.RegisterBackend] registry so that third-party and experimental backends (e.g.
+// pebble) are selectable the same way as the built-in ones.
 func GetDatabase(ctx context.Context, t *testing.T, dbt DatabaseType) (client.DB, string, error) {
-	switch dbt {
-	case badgerIMType:
-		db, err := NewBadgerMemoryDB(ctx, db.WithUpdateEvents())
-		if err != nil {
-			return nil, "", err
-		}
-		return db, "", nil
+	factory, isRegistered := datastore.GetRegisteredBackend(string(dbt))
+	if !isRegistered {
+		return nil, "", errors.New(fmt.Sprintf("no datastore backend registered with name %q", dbt))
+	}
 
-	case badgerFileType:
-		db, path, err := NewBadgerFileDB(ctx, t)
-		if err != nil {
-			return nil, "", err
-		}
-		return db, path, nil
+	path, err := backendPath(t, dbt)
+	if err != nil {
+		return nil, "", err
+	}
 
-	case defraIMType:
-		db, err := NewInMemoryDB(ctx)
-		if err != nil {
-			return nil, "", err
-		}
-		return db, "", nil
+	rootstore, err := factory(ctx, path)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return nil, "", nil
+	var faultInjector *faultds.Datastore
+	if faultSeedConfigured {
+		faultInjector = faultds.Wrap(rootstore, faultSeed, faultds.Config{})
+		rootstore = faultInjector
+	}
+
+	store, err := db.NewDB(ctx, rootstore, db.WithUpdateEvents())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if faultInjector != nil {
+		faultInjectors[store] = faultInjector
+	}
+
+	return store, path, nil
+}
+
+// backendPath returns the filesystem path a file-backed backend should use, or "" for an
+// in-memory one.
+func backendPath(t *testing.T, dbt DatabaseType) (string, error) {
+	if dbt != badgerFileType {
+		return "", nil
+	}
+
+	if databaseDir != "" {
+		return databaseDir, nil
+	}
+	if rootDatabaseDir != "" {
+		return path.Join(rootDatabaseDir, t.Name()), nil
+	}
+	return t.TempDir(), nil
 }
 
 // ExecuteTestCase executes the given TestCase against the configured database
@@ -297,13 +385,18 @@ func executeTestCase(
 	flattenActions(&testCase)
 	startActionIndex, endActionIndex := getActionRange(testCase)
 	txns := []datastore.Txn{}
+	oracle := newTxnOracle()
+	observer := newTestObserver()
+	replay := newReplaySession(t, testCase)
+	defer replay.close(t)
 	allActionsDone := make(chan struct{})
+	liveSubs := map[int]*liveSubscription{}
 	resultsChans := []chan func(){}
 	syncChans := []chan struct{}{}
 	nodeAddresses := []string{}
 	// The actions responsible for configuring the node
 	nodeConfigs := []config.Config{}
-	nodes, dbPaths := getStartingNodes(ctx, t, dbt, testCase)
+	nodes, dbPaths := getStartingNodes(ctx, t, dbt, testCase, replay)
 	// It is very important that the databases are always closed, otherwise resources will leak
 	// as tests run.  This is particularly important for file based datastores.
 	defer closeNodes(ctx, t, nodes)
@@ -314,6 +407,7 @@ func executeTestCase(
 	collections := getCollections(ctx, t, nodes, collectionNames)
 	// documents are by collection (index), these are not node specific.
 	documents := getDocuments(ctx, t, testCase, collections, startActionIndex)
+	snapshots := map[string][]byte{}
 
 	for i := startActionIndex; i <= endActionIndex; i++ {
 		// declare default database for ease of use
@@ -330,7 +424,7 @@ func executeTestCase(
 				return
 			}
 			cfg := action()
-			node, address, path := configureNode(ctx, t, dbt, cfg)
+			node, address, path := configureNode(ctx, t, dbt, cfg, replay)
 			nodes = append(nodes, node)
 			nodeAddresses = append(nodeAddresses, address)
 			dbPaths = append(dbPaths, path)
@@ -341,7 +435,7 @@ func executeTestCase(
 			// gracefully as part of the node closure.
 			syncChans = append(
 				syncChans,
-				restartNodes(ctx, t, testCase, dbt, i, nodes, dbPaths, nodeAddresses, nodeConfigs)...,
+				restartNodes(ctx, t, testCase, dbt, i, nodes, dbPaths, nodeAddresses, nodeConfigs, replay)...,
 			)
 
 			// If the db was restarted we need to refresh the collection definitions as the old instances
@@ -373,31 +467,49 @@ func executeTestCase(
 			// If the schema was updated we need to refresh the collection definitions.
 			collections = getCollections(ctx, t, nodes, collectionNames)
 
+		case SchemaMerge:
+			mergeSchema(ctx, t, nodes, testCase, action)
+			// If the schema was updated we need to refresh the collection definitions.
+			collections = getCollections(ctx, t, nodes, collectionNames)
+
 		case CreateDoc:
-			documents = createDoc(ctx, t, testCase, nodes, collections, documents, action)
+			documents = createDoc(ctx, t, testCase, nodes, collections, documents, observer, replay, action)
 
 		case DeleteDoc:
-			deleteDoc(ctx, t, testCase, nodes, collections, documents, action)
+			deleteDoc(ctx, t, testCase, nodes, collections, documents, replay, action)
 
 		case UpdateDoc:
-			updateDoc(ctx, t, testCase, nodes, collections, documents, action)
+			updateDoc(ctx, t, testCase, nodes, collections, documents, replay, action)
 
 		case TransactionRequest2:
-			txns = executeTransactionRequest(ctx, t, db, txns, testCase, action)
+			txns = executeTransactionRequest(ctx, t, db, txns, testCase, oracle, observer, action)
 
 		case TransactionCommit:
-			commitTransaction(ctx, t, txns, testCase, action)
+			commitTransaction(ctx, t, txns, testCase, oracle, observer, action)
+
+		case ConcurrentTransactions:
+			txns = executeConcurrentTransactions(ctx, t, db, txns, testCase, oracle, observer, action)
 
 		case SubscriptionRequest:
+			live := registerLiveSubscription(liveSubs, action.SubscriptionID, action.Request)
 			var resultsChan chan func()
-			resultsChan, done = executeSubscriptionRequest(ctx, t, allActionsDone, db, testCase, action)
+			resultsChan, done = executeSubscriptionRequest(ctx, t, allActionsDone, db, testCase, replay, live, action)
 			if done {
 				return
 			}
 			resultsChans = append(resultsChans, resultsChan)
 
+		case ExpectSubscriptionEvent:
+			executeExpectSubscriptionEvent(t, testCase, liveSubs, action)
+
+		case ResumeSubscription:
+			resumeSubscription(ctx, t, allActionsDone, db, testCase, liveSubs, action)
+
+		case CloseSubscription:
+			closeSubscription(liveSubs, action)
+
 		case Request:
-			executeRequest(ctx, t, nodes, testCase, action)
+			executeRequest(ctx, t, nodes, testCase, observer, replay, action)
 
 		case IntrospectionRequest:
 			assertIntrospectionResults(ctx, t, testCase.Description, db, action)
@@ -408,6 +520,30 @@ func executeTestCase(
 		case WaitForSync:
 			waitForSync(t, testCase, action, syncChans)
 
+		case Snapshot:
+			takeSnapshot(ctx, t, nodes, snapshots, action)
+
+		case Restore:
+			restoreSnapshot(ctx, t, nodes, snapshots, action)
+
+		case FaultInjection:
+			injectFaults(t, nodes, action)
+
+		case MetricsAssert:
+			assertMetrics(ctx, t, nodes, action)
+
+		case ExpectEvents:
+			executeExpectEvents(t, testCase, observer, action)
+
+		case AssertNoEvents:
+			executeAssertNoEvents(t, testCase, observer, action)
+
+		case FailPoint:
+			installFailPoint(nodes, action)
+
+		case ClearFailPoints:
+			clearFailPoints(nodes, action)
+
 		case SetupComplete:
 			// no-op, just continue.
 
@@ -430,6 +566,8 @@ func executeTestCase(
 			assert.Fail(t, "timeout occurred while waiting for data stream", testCase.Description)
 		}
 	}
+
+	oracle.verify(t, testCase)
 }
 
 // closeNodes closes all the given nodes, ensuring that resources are properly released.
@@ -444,6 +582,13 @@ func closeNodes(
 			require.NoError(t, err)
 		}
 		node.DB.Close(ctx)
+		// Gauges (peer count, replicator lag, subscription fan-out, ...) are registered against
+		// a per-node registry keyed by this *node.Node - this node is done for good (restartNodes
+		// always replaces nodes[i] with a new *node.Node rather than reusing this pointer), so
+		// forget its entry entirely instead of just resetting it, or it stays reachable forever.
+		node.UnregisterMetrics()
+		// Same reasoning applies to any FailPoints installed against this node - forget them too.
+		forgetFailPoints(node)
 	}
 }
 
@@ -575,6 +720,7 @@ func getStartingNodes(
 	t *testing.T,
 	dbt DatabaseType,
 	testCase TestCase,
+	replay *replaySession,
 ) ([]*node.Node, []string) {
 	hasExplicitNode := false
 	for _, action := range testCase.Actions {
@@ -586,7 +732,7 @@ func getStartingNodes(
 
 	// If nodes have not been explicitly configured via actions, setup a default one.
 	if !hasExplicitNode {
-		db, path, err := GetDatabase(ctx, t, dbt)
+		db, path, err := GetDatabase(ctx, t, effectiveDatabaseType(dbt, replay))
 		require.Nil(t, err)
 
 		return []*node.Node{
@@ -611,7 +757,9 @@ func restartNodes(
 	dbPaths []string,
 	nodeAddresses []string,
 	configureActions []config.Config,
+	replay *replaySession,
 ) []chan struct{} {
+	dbt = effectiveDatabaseType(dbt, replay)
 	if dbt == badgerIMType || dbt == defraIMType {
 		return nil
 	}
@@ -631,6 +779,9 @@ func restartNodes(
 			nodes[i] = &node.Node{
 				DB: db,
 			}
+			// This incarnation is a new *node.Node; closeNodes dropped the previous one's
+			// registry entirely - start this one with a clean registry explicitly.
+			nodes[i].ResetMetrics()
 			continue
 		}
 
@@ -654,6 +805,10 @@ func restartNodes(
 			require.NoError(t, err)
 		}
 
+		// The previous incarnation's registry was dropped by closeNodes above; start this one
+		// with a fresh registry too so its first-ever gauge values aren't mistaken for carried
+		// over state.
+		n.ResetMetrics()
 		nodes[i] = n
 	}
 
@@ -730,13 +885,14 @@ func configureNode(
 	t *testing.T,
 	dbt DatabaseType,
 	cfg config.Config,
+	replay *replaySession,
 ) (*node.Node, string, string) {
 	// WARNING: This is a horrible hack both deduplicates/randomizes peer IDs
 	// And affects where libp2p(?) stores some values on the file system, even when using
 	// an in memory store.
 	cfg.Datastore.Badger.Path = t.TempDir()
 
-	db, path, err := GetDatabase(ctx, t, dbt) //disable change dector, or allow it?
+	db, path, err := GetDatabase(ctx, t, effectiveDatabaseType(dbt, replay)) //disable change dector, or allow it?
 	require.NoError(t, err)
 
 	var n *node.Node
@@ -847,6 +1003,23 @@ func patchSchema(
 	}
 }
 
+// mergeSchema applies a [SchemaMerge] action's strategic merge patch document via
+// [client.Store.MergeSchema], mirroring [patchSchema]'s handling of [SchemaPatch].
+func mergeSchema(
+	ctx context.Context,
+	t *testing.T,
+	nodes []*node.Node,
+	testCase TestCase,
+	action SchemaMerge,
+) {
+	for _, node := range getNodes(action.NodeID, nodes) {
+		err := node.DB.MergeSchema(ctx, action.Patch)
+		expectedErrorRaised := AssertError(t, testCase.Description, err, action.ExpectedError)
+
+		assertExpectedErrorRaised(t, testCase.Description, action.ExpectedError, expectedErrorRaised)
+	}
+}
+
 // createDoc creates a document using the collection api and caches it in the
 // given documents slice.
 func createDoc(
@@ -856,6 +1029,8 @@ func createDoc(
 	nodes []*node.Node,
 	nodeCollections [][]client.Collection,
 	documents [][]*client.Document,
+	observer *TestObserver,
+	replay *replaySession,
 	action CreateDoc,
 ) [][]*client.Document {
 	// All the docs should be identical, and we only need 1 copy so taking the last
@@ -872,8 +1047,15 @@ func createDoc(
 		err = withRetry(
 			actionNodes,
 			nodeID,
-			func() error { return collections[action.CollectionID].Save(ctx, doc) },
+			replay,
+			func() error {
+				if fpErr := checkFailPoint(actionNodes[nodeID], immutable.Some(action.CollectionID)); fpErr != nil {
+					return fpErr
+				}
+				return collections[action.CollectionID].Save(ctx, doc)
+			},
 		)
+		observer.record(eventSave, nodeID, immutable.None[int](), action.Doc, err)
 		if AssertError(t, testCase.Description, err, action.ExpectedError) {
 			return nil
 		}
@@ -899,6 +1081,7 @@ func deleteDoc(
 	nodes []*node.Node,
 	nodeCollections [][]client.Collection,
 	documents [][]*client.Document,
+	replay *replaySession,
 	action DeleteDoc,
 ) {
 	doc := documents[action.CollectionID][action.DocID]
@@ -909,7 +1092,11 @@ func deleteDoc(
 		err := withRetry(
 			actionNodes,
 			nodeID,
+			replay,
 			func() error {
+				if fpErr := checkFailPoint(actionNodes[nodeID], immutable.Some(action.CollectionID)); fpErr != nil {
+					return fpErr
+				}
 				_, err := collections[action.CollectionID].DeleteWithKey(ctx, doc.Key())
 				return err
 			},
@@ -928,6 +1115,7 @@ func updateDoc(
 	nodes []*node.Node,
 	nodeCollections [][]client.Collection,
 	documents [][]*client.Document,
+	replay *replaySession,
 	action UpdateDoc,
 ) {
 	doc := documents[action.CollectionID][action.DocID]
@@ -943,7 +1131,13 @@ func updateDoc(
 		err := withRetry(
 			actionNodes,
 			nodeID,
-			func() error { return collections[action.CollectionID].Save(ctx, doc) },
+			replay,
+			func() error {
+				if fpErr := checkFailPoint(actionNodes[nodeID], immutable.Some(action.CollectionID)); fpErr != nil {
+					return fpErr
+				}
+				return collections[action.CollectionID].Save(ctx, doc)
+			},
 		)
 		expectedErrorRaised = AssertError(t, testCase.Description, err, action.ExpectedError)
 	}
@@ -957,16 +1151,20 @@ func updateDoc(
 // If a P2P-sync commit for the given document is already in progress this
 // Save call can fail as the transaction will conflict. We dont want to worry
 // about this in our tests so we just retry a few times until it works (or the
-// retry limit is breached - important incase this is a different error)
+// retry limit is breached - important incase this is a different error).
+//
+// The sleep between attempts goes through replay so a recorded run's retry timing can be
+// reproduced (and skipped) deterministically on replay - see [replaySession.sleep].
 func withRetry(
 	nodes []*node.Node,
 	nodeID int,
+	replay *replaySession,
 	action func() error,
 ) error {
 	for i := 0; i < nodes[nodeID].MaxTxnRetries(); i++ {
 		err := action()
 		if err != nil && errors.Is(err, badgerds.ErrTxnConflict) {
-			time.Sleep(100 * time.Millisecond)
+			replay.sleep(100 * time.Millisecond)
 			continue
 		}
 		return err
@@ -985,6 +1183,33 @@ func executeTransactionRequest(
 	db client.DB,
 	txns []datastore.Txn,
 	testCase TestCase,
+	oracle *txnOracle,
+	observer *TestObserver,
+	action TransactionRequest2,
+) []datastore.Txn {
+	txns = ensureTransaction(ctx, t, db, txns, testCase, oracle, observer, action)
+	if txns == nil {
+		return nil
+	}
+
+	if runTransactionRequest(ctx, t, db, txns[action.TransactionID], testCase, observer, action) {
+		return nil
+	}
+
+	return txns
+}
+
+// ensureTransaction creates and caches a new transaction for action.TransactionID if one does not
+// already exist, extending txns so this TransactionID can fit. If creation fails the transaction
+// is discarded and nil is returned.
+func ensureTransaction(
+	ctx context.Context,
+	t *testing.T,
+	db client.DB,
+	txns []datastore.Txn,
+	testCase TestCase,
+	oracle *txnOracle,
+	observer *TestObserver,
 	action TransactionRequest2,
 ) []datastore.Txn {
 	if action.TransactionID >= len(txns) {
@@ -995,15 +1220,34 @@ func executeTransactionRequest(
 	if txns[action.TransactionID] == nil {
 		// Create a new transaction if one does not already exist.
 		txn, err := db.NewTxn(ctx, false)
+		observer.record(eventTxnBegin, 0, immutable.Some(action.TransactionID), "", err)
 		if AssertError(t, testCase.Description, err, action.ExpectedError) {
 			txn.Discard(ctx)
 			return nil
 		}
 
 		txns[action.TransactionID] = txn
+		oracle.recordStart(action.TransactionID)
 	}
 
-	result := db.WithTxn(txns[action.TransactionID]).ExecRequest(ctx, action.Request)
+	return txns
+}
+
+// runTransactionRequest executes action's GraphQL request against the already-open txn. If the
+// expected error was raised the transaction is discarded and true is returned.
+func runTransactionRequest(
+	ctx context.Context,
+	t *testing.T,
+	db client.DB,
+	txn datastore.Txn,
+	testCase TestCase,
+	observer *TestObserver,
+	action TransactionRequest2,
+) bool {
+	result := db.WithTxn(txn).ExecRequest(ctx, action.Request)
+	observer.record(
+		eventExecRequest, 0, immutable.Some(action.TransactionID), action.Request, firstErr(result.GQL.Errors),
+	)
 	expectedErrorRaised := assertRequestResults(
 		ctx,
 		t,
@@ -1021,27 +1265,35 @@ func executeTransactionRequest(
 	if expectedErrorRaised {
 		// Make sure to discard the transaction before exit, else an unwanted error
 		// may surface later (e.g. on database close).
-		txns[action.TransactionID].Discard(ctx)
-		return nil
+		txn.Discard(ctx)
+		observer.record(eventTxnDiscard, 0, immutable.Some(action.TransactionID), "", nil)
+		return true
 	}
 
-	return txns
+	return false
 }
 
 // commitTransaction commits the given transaction.
 //
 // Will panic if the given transaction does not exist. Discards the transaction if
-// an error is returned on commit.
+// an error is returned on commit, otherwise records its read/write sets with oracle.
 func commitTransaction(
 	ctx context.Context,
 	t *testing.T,
 	txns []datastore.Txn,
 	testCase TestCase,
+	oracle *txnOracle,
+	observer *TestObserver,
 	action TransactionCommit,
 ) {
-	err := txns[action.TransactionID].Commit(ctx)
+	txn := txns[action.TransactionID]
+	err := txn.Commit(ctx)
 	if err != nil {
-		txns[action.TransactionID].Discard(ctx)
+		txn.Discard(ctx)
+		observer.record(eventTxnDiscard, 0, immutable.Some(action.TransactionID), "", err)
+	} else {
+		oracle.recordCommit(action.TransactionID, txn.ReadSet(), txn.WriteSet())
+		observer.record(eventTxnCommit, 0, immutable.Some(action.TransactionID), "", nil)
 	}
 
 	expectedErrorRaised := AssertError(t, testCase.Description, err, action.ExpectedError)
@@ -1055,11 +1307,21 @@ func executeRequest(
 	t *testing.T,
 	nodes []*node.Node,
 	testCase TestCase,
+	observer *TestObserver,
+	replay *replaySession,
 	action Request,
 ) {
 	var expectedErrorRaised bool
 	for nodeID, node := range getNodes(action.NodeID, nodes) {
-		result := node.DB.ExecRequest(ctx, action.Request)
+		var result *client.RequestResult
+		if fpErr := checkFailPoint(node, immutable.None[int]()); fpErr != nil {
+			result = &client.RequestResult{GQL: client.GQLResult{Errors: []error{fpErr}}}
+		} else {
+			result = node.DB.ExecRequest(ctx, action.Request)
+		}
+		err := firstErr(result.GQL.Errors)
+		observer.record(eventExecRequest, nodeID, immutable.None[int](), action.Request, err)
+		replay.recordRequest(eventExecRequest, action.Request, err)
 
 		anyOfByFieldKey := map[docFieldKey][]any{}
 		expectedErrorRaised = assertRequestResults(
@@ -1091,6 +1353,8 @@ func executeSubscriptionRequest(
 	allActionsDone chan struct{},
 	db client.DB,
 	testCase TestCase,
+	replay *replaySession,
+	live *liveSubscription,
 	action SubscriptionRequest,
 ) (chan func(), bool) {
 	subscriptionAssert := make(chan func())
@@ -1114,6 +1378,7 @@ func executeSubscriptionRequest(
 				sData, _ := sResult.Data.([]map[string]any)
 				errs = append(errs, sResult.Errors...)
 				data = append(data, sData...)
+				live.push(sData, sResult.Errors)
 
 				if len(data) >= len(action.Results) {
 					expectedDataRecieved = true
@@ -1128,6 +1393,7 @@ func executeSubscriptionRequest(
 					Data:   data,
 					Errors: errs,
 				}
+				replay.recordRequest(eventExecRequest, action.Request, firstErr(errs))
 
 				subscriptionAssert <- func() {
 					// This assert should be executed from the main test routine